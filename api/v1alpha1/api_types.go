@@ -18,9 +18,13 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"time"
+
 	commonapi "github.com/openmcp-project/openmcp-operator/api/common"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -40,18 +44,132 @@ type FooSpec struct {
 	// +optional
 	// opencontrolplane-gen:replace Foo=KIND
 	Foo *string `json:"foo,omitempty"`
+
+	// version of the domain service that is installed on the MCP cluster, e.g. v1.1.0.
+	// The default version of the ProviderConfig is installed if not set. Downgrades and upgrades that skip a major version are rejected.
+	// +kubebuilder:validation:Pattern=`^v[0-9]+\.[0-9]+\.[0-9]+$`
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// opencontrolplane-gen:replace Foo=KIND
+	// deletionPolicy defines what happens to the managed resources in the MCP cluster when the Foo is deleted.
+	// Delete removes them once no user resources remain, Orphan leaves them and all user resources in place,
+	// ForceDelete deletes remaining user resources first.
+	// +kubebuilder:validation:Enum=Delete;Orphan;ForceDelete
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// forceDeleteGracePeriod is the time remaining user resources are given to be deleted with the ForceDelete policy.
+	// Finalizers of user resources that still exist afterwards are removed. Defaults to 5m.
+	// +optional
+	ForceDeleteGracePeriod *metav1.Duration `json:"forceDeleteGracePeriod,omitempty"`
 }
 
+// opencontrolplane-gen:replace Foo=KIND
+// DeletionPolicy defines what happens to the managed resources when a Foo is deleted.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the managed resources once no user resources remain.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyOrphan removes the finalizer and leaves the managed resources and user resources in the MCP cluster in place.
+	// The objects in the workload cluster are deleted, as they hold credentials for the MCP cluster.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+	// DeletionPolicyForceDelete deletes remaining user resources before the managed resources are deleted.
+	DeletionPolicyForceDelete DeletionPolicy = "ForceDelete"
+)
+
+// DefaultForceDeleteGracePeriod is used if no grace period is specified for the ForceDelete policy.
+const DefaultForceDeleteGracePeriod = 5 * time.Minute
+
 // opencontrolplane-gen:replace Foo=KIND
 // FooStatus defines the observed state of Foo.
 // opencontrolplane-gen:replace Foo=KIND
 type FooStatus struct {
 	commonapi.Status `json:",inline"`
+
+	// installedVersion is the version of the domain service that is installed on the MCP cluster.
+	// It is updated once an installation or upgrade has completed.
+	// +optional
+	InstalledVersion string `json:"installedVersion,omitempty"`
+
+	// deletionPolicy is the deletion policy that is applied while the resource is being deleted.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// blockingResources lists the first user resources in the MCP cluster that block the deletion.
+	// +optional
+	BlockingResources []ResourceReference `json:"blockingResources,omitempty"`
+
+	// inventory lists the objects that have been applied to the MCP and workload cluster.
+	// Objects that are no longer part of the inventory after a reconciliation are pruned.
+	// +optional
+	Inventory []InventoryEntry `json:"inventory,omitempty"`
+}
+
+// InventoryCluster identifies the cluster an inventory entry belongs to.
+type InventoryCluster string
+
+const (
+	// InventoryClusterMCP is the MCP cluster.
+	InventoryClusterMCP InventoryCluster = "MCP"
+	// InventoryClusterWorkload is the workload cluster.
+	InventoryClusterWorkload InventoryCluster = "Workload"
+)
+
+// InventoryEntry identifies an object that has been applied to a cluster.
+type InventoryEntry struct {
+	// cluster is the cluster the object has been applied to.
+	// +kubebuilder:validation:Enum=MCP;Workload
+	Cluster InventoryCluster `json:"cluster"`
+	// group of the object, empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// version of the object.
+	Version string `json:"version"`
+	// kind of the object.
+	Kind string `json:"kind"`
+	// namespace of the object, empty for cluster-scoped objects.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// name of the object.
+	Name string `json:"name"`
+	// uid of the object when it has been applied.
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+}
+
+// GroupVersionKind returns the GroupVersionKind of the object.
+func (e InventoryEntry) GroupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: e.Group, Version: e.Version, Kind: e.Kind}
+}
+
+// ResourceReference identifies a resource in another cluster.
+type ResourceReference struct {
+	// apiVersion of the resource.
+	APIVersion string `json:"apiVersion"`
+	// kind of the resource.
+	Kind string `json:"kind"`
+	// namespace of the resource, empty for cluster-scoped resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// name of the resource.
+	Name string `json:"name"`
+}
+
+// String returns the namespace and name of the resource.
+func (r ResourceReference) String() string {
+	if r.Namespace == "" {
+		return r.Name
+	}
+	return r.Namespace + "/" + r.Name
 }
 
 // opencontrolplane-gen:replace Foo=KIND foo=KIND_LOWER
 // Foo is the Schema for the foos API
 // +kubebuilder:object:root=true
+// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:JSONPath=`.status.phase`,name="Phase",type=string
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
@@ -131,3 +249,23 @@ func (o *Foo) SetPhase(phase string) {
 func (o *Foo) SetObservedGeneration(gen int64) {
 	o.Status.ObservedGeneration = gen
 }
+
+// opencontrolplane-gen:replace Foo=KIND
+// GetDeletionPolicy returns the deletion policy of the Foo resource, Delete if none is set.
+// opencontrolplane-gen:replace Foo=KIND
+func (o *Foo) GetDeletionPolicy() DeletionPolicy {
+	if o.Spec.DeletionPolicy == "" {
+		return DeletionPolicyDelete
+	}
+	return o.Spec.DeletionPolicy
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// GetForceDeleteGracePeriod returns the grace period for the ForceDelete policy of the Foo resource.
+// opencontrolplane-gen:replace Foo=KIND
+func (o *Foo) GetForceDeleteGracePeriod() time.Duration {
+	if o.Spec.ForceDeleteGracePeriod == nil {
+		return DefaultForceDeleteGracePeriod
+	}
+	return o.Spec.ForceDeleteGracePeriod.Duration
+}
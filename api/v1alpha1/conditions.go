@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Condition types reported in the status of service provider resources.
+const (
+	// ConditionTypeReady indicates that the service is fully reconciled and usable.
+	ConditionTypeReady = "Ready"
+	// ConditionTypeMCPAccessReady indicates that access to the MCP cluster has been granted.
+	ConditionTypeMCPAccessReady = "MCPAccessReady"
+	// ConditionTypeManagedResourcesApplied indicates that all managed resources have been applied.
+	ConditionTypeManagedResourcesApplied = "ManagedResourcesApplied"
+	// ConditionTypeDeletionBlocked indicates that the deletion is blocked by remaining user resources.
+	ConditionTypeDeletionBlocked = "DeletionBlocked"
+	// ConditionTypeDegraded indicates that the last reconciliation failed.
+	ConditionTypeDegraded = "Degraded"
+	// ConditionTypeProgressing indicates that the domain service is being installed or upgraded.
+	ConditionTypeProgressing = "Progressing"
+	// ConditionTypePaused indicates that reconciliation is paused by the ignore operation annotation.
+	ConditionTypePaused = "Paused"
+	// ConditionTypeAvailable indicates that a ProviderConfig is valid and can be used.
+	ConditionTypeAvailable = "Available"
+)
+
+// Condition reasons reported in the status of service provider resources.
+const (
+	// ReasonReconciling is used while a reconciliation is in progress.
+	ReasonReconciling = "Reconciling"
+	// ReasonReconciled is used when a reconciliation finished successfully.
+	ReasonReconciled = "Reconciled"
+	// ReasonTerminating is used while the resource is being deleted.
+	ReasonTerminating = "Terminating"
+	// ReasonWaitingForAccess is used while access to a cluster has not been granted yet.
+	ReasonWaitingForAccess = "WaitingForAccess"
+	// ReasonAccessGranted is used when access to a cluster has been granted.
+	ReasonAccessGranted = "AccessGranted"
+	// ReasonApplied is used when managed resources have been applied.
+	ReasonApplied = "Applied"
+	// ReasonApplyFailed is used when managed resources could not be applied.
+	ReasonApplyFailed = "ApplyFailed"
+	// ReasonInvalidVersion is used when the requested version of the domain service is unknown.
+	ReasonInvalidVersion = "InvalidVersion"
+	// ReasonUpgradeNotAllowed is used when the requested version change of the domain service is not a supported upgrade.
+	ReasonUpgradeNotAllowed = "UpgradeNotAllowed"
+	// ReasonInstalling is used while the domain service is being installed.
+	ReasonInstalling = "Installing"
+	// ReasonUpgrading is used while the domain service is being upgraded.
+	ReasonUpgrading = "Upgrading"
+	// ReasonVersionInstalled is used when the requested version of the domain service has been installed.
+	ReasonVersionInstalled = "VersionInstalled"
+	// ReasonFieldConflict is used when managed resources could not be applied because fields are owned by other field managers.
+	ReasonFieldConflict = "FieldConflict"
+	// ReasonDeleteFailed is used when managed resources could not be deleted.
+	ReasonDeleteFailed = "DeleteFailed"
+	// ReasonPruneFailed is used when managed resources that are no longer desired could not be deleted.
+	ReasonPruneFailed = "PruneFailed"
+	// ReasonCRDsInUse is used while CRDs that are no longer desired are kept because instances of them remain.
+	ReasonCRDsInUse = "CRDsInUse"
+	// ReasonListFailed is used when remaining user resources could not be listed.
+	ReasonListFailed = "ListFailed"
+	// ReasonUserResourcesPresent is used when user resources block the deletion.
+	ReasonUserResourcesPresent = "UserResourcesPresent"
+	// ReasonNoUserResources is used when no user resources block the deletion.
+	ReasonNoUserResources = "NoUserResources"
+	// ReasonOrphaned is used when the managed resources are left in place by the Orphan deletion policy.
+	ReasonOrphaned = "Orphaned"
+	// ReasonForceDeleting is used while remaining user resources are deleted by the ForceDelete deletion policy.
+	ReasonForceDeleting = "ForceDeleting"
+	// ReasonOperationIgnore is used when reconciliation is paused by the ignore operation annotation.
+	ReasonOperationIgnore = "OperationIgnore"
+	// ReasonValid is used when a ProviderConfig passed validation.
+	ReasonValid = "Valid"
+	// ReasonInvalidSpec is used when a ProviderConfig failed validation.
+	ReasonInvalidSpec = "InvalidSpec"
+)
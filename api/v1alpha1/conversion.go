@@ -0,0 +1,27 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// v1alpha1 is the hub version that all other versions of the API are converted to and from.
+// It is also the storage version of the CRDs and the version the controllers work with.
+
+// opencontrolplane-gen:replace Foo=KIND
+// Hub marks Foo as the conversion hub.
+func (*Foo) Hub() {}
+
+// Hub marks ProviderConfig as the conversion hub.
+func (*ProviderConfig) Hub() {}
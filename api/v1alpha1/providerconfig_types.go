@@ -17,10 +17,22 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"slices"
 	"time"
 
+	commonapi "github.com/openmcp-project/openmcp-operator/api/common"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// DefaultPollInterval is used if no poll interval is set in a ProviderConfig.
+	DefaultPollInterval = time.Minute
+	// MinPollInterval is the shortest poll interval accepted in a ProviderConfig.
+	MinPollInterval = 10 * time.Second
+	// MaxPollInterval is the longest poll interval accepted in a ProviderConfig.
+	MaxPollInterval = 24 * time.Hour
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -33,11 +45,42 @@ type ProviderConfigSpec struct {
 	// The following markers will use OpenAPI v3 schema to validate the value
 	// More info: https://book.kubebuilder.io/reference/markers/crd-validation.html
 
-	// foo is an example field of ProviderConfig. Edit providerconfig_types.go to remove/update
+	// pollInterval is the interval in which service objects are reconciled again
+	// to detect and correct drift of the managed resources.
 	// +optional
 	// +kubebuilder:default:="1m"
 	// +kubebuilder:validation:Format=duration
 	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
+	// imagePullSecrets references secrets in the namespace of the service provider pod
+	// that are required to pull the images of the managed service.
+	// +optional
+	ImagePullSecrets []commonapi.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// forceConflicts takes over the ownership of fields of managed resources that are owned by other field managers,
+	// e.g. another controller or kubectl edit. If not set, conflicting fields are not applied and the conflict is reported
+	// in the status and the events of the service objects.
+	// +optional
+	ForceConflicts bool `json:"forceConflicts,omitempty"`
+
+	// versions lists the versions of the domain service that may be installed on the MCP clusters, e.g. v1.1.0.
+	// All versions known to the service provider are available if not set.
+	// +listType=set
+	// +kubebuilder:validation:items:Pattern=`^v[0-9]+\.[0-9]+\.[0-9]+$`
+	// +optional
+	Versions []string `json:"versions,omitempty"`
+
+	// defaultVersion is the version of the domain service that is installed for service objects that do not request a version.
+	// The latest available version is installed if not set.
+	// +kubebuilder:validation:Pattern=`^v[0-9]+\.[0-9]+\.[0-9]+$`
+	// +optional
+	DefaultVersion string `json:"defaultVersion,omitempty"`
+
+	// serviceControllerImage is the image of the domain service controller that is deployed to the workload cluster
+	// for every service object. The installed version of the domain service is used as tag if the image has neither a tag nor a digest.
+	// No controller is deployed if not set.
+	// +optional
+	ServiceControllerImage string `json:"serviceControllerImage,omitempty"`
 }
 
 // ProviderConfigStatus defines the observed state of ProviderConfig.
@@ -61,10 +104,23 @@ type ProviderConfigStatus struct {
 	// +listMapKey=type
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// observedGeneration is the generation of the ProviderConfig that was last validated.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// usedBy lists the first service objects that are currently reconciled with this ProviderConfig, sorted by namespace and name.
+	// +optional
+	UsedBy []commonapi.ObjectReference `json:"usedBy,omitempty"`
+
+	// usedByCount is the number of service objects that are currently reconciled with this ProviderConfig.
+	// +optional
+	UsedByCount int32 `json:"usedByCount,omitempty"`
 }
 
 // ProviderConfig is the Schema for the providerconfigs API
 // +kubebuilder:object:root=true
+// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:metadata:labels="openmcp.cloud/cluster=platform"
@@ -101,7 +157,41 @@ func init() {
 }
 
 // PollInterval returns the poll interval duration from the spec.
+// DefaultPollInterval is returned if no poll interval is set.
 func (o *ProviderConfig) PollInterval() time.Duration {
-	// TODO pollInterval has to be required
+	if o == nil || o.Spec.PollInterval == nil {
+		return DefaultPollInterval
+	}
 	return o.Spec.PollInterval.Duration
 }
+
+// GetForceConflicts returns whether fields owned by other field managers are taken over, false if no ProviderConfig is set.
+func (o *ProviderConfig) GetForceConflicts() bool {
+	return o != nil && o.Spec.ForceConflicts
+}
+
+// GetConditions returns the conditions of the ProviderConfig resource
+func (o *ProviderConfig) GetConditions() *[]metav1.Condition {
+	return &o.Status.Conditions
+}
+
+// ValidateSpec returns the static validation errors of the ProviderConfig spec.
+func (o *ProviderConfig) ValidateSpec() field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if o.Spec.PollInterval != nil {
+		if d := o.Spec.PollInterval.Duration; d < MinPollInterval || d > MaxPollInterval {
+			errs = append(errs, field.Invalid(specPath.Child("pollInterval"), o.Spec.PollInterval.String(),
+				"must be between "+MinPollInterval.String()+" and "+MaxPollInterval.String()))
+		}
+	}
+	for i, ref := range o.Spec.ImagePullSecrets {
+		if ref.Name == "" {
+			errs = append(errs, field.Required(specPath.Child("imagePullSecrets").Index(i).Child("name"), "secret name must not be empty"))
+		}
+	}
+	if o.Spec.DefaultVersion != "" && len(o.Spec.Versions) > 0 && !slices.Contains(o.Spec.Versions, o.Spec.DefaultVersion) {
+		errs = append(errs, field.NotSupported(specPath.Child("defaultVersion"), o.Spec.DefaultVersion, o.Spec.Versions))
+	}
+	return errs
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package v1beta1
+
+import (
+	commonapi "github.com/openmcp-project/openmcp-operator/api/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// opencontrolplane-gen:replace Foo=KIND
+// FooSpec defines the desired state of Foo
+// opencontrolplane-gen:replace Foo=KIND
+type FooSpec struct {
+	// opencontrolplane-gen:replace Foo=KIND
+	// foo is an example field of Foo. Edit api_types.go to remove/update
+	// +optional
+	// opencontrolplane-gen:replace Foo=KIND
+	Foo *string `json:"foo,omitempty"`
+
+	// version of the domain service that is installed on the MCP cluster, e.g. v1.1.0.
+	// The default version of the ProviderConfig is installed if not set. Downgrades and upgrades that skip a major version are rejected.
+	// +kubebuilder:validation:Pattern=`^v[0-9]+\.[0-9]+\.[0-9]+$`
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// opencontrolplane-gen:replace Foo=KIND
+	// deletion defines what happens to the managed resources in the MCP cluster when the Foo is deleted.
+	// +kubebuilder:default={}
+	// +optional
+	Deletion DeletionSpec `json:"deletion,omitempty,omitzero"`
+}
+
+// DeletionSpec defines how the managed resources are removed when a service object is deleted.
+type DeletionSpec struct {
+	// policy is the deletion policy.
+	// Delete removes the managed resources once no user resources remain, Orphan leaves them and all user resources in place,
+	// ForceDelete deletes remaining user resources first.
+	// +kubebuilder:validation:Enum=Delete;Orphan;ForceDelete
+	// +kubebuilder:default=Delete
+	// +optional
+	Policy DeletionPolicy `json:"policy,omitempty"`
+
+	// forceGracePeriod is the time remaining user resources are given to be deleted with the ForceDelete policy.
+	// Finalizers of user resources that still exist afterwards are removed. Defaults to 5m.
+	// +optional
+	ForceGracePeriod *metav1.Duration `json:"forceGracePeriod,omitempty"`
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// DeletionPolicy defines what happens to the managed resources when a Foo is deleted.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the managed resources once no user resources remain.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyOrphan removes the finalizer and leaves the managed resources and user resources in the MCP cluster in place.
+	// The objects in the workload cluster are deleted, as they hold credentials for the MCP cluster.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+	// DeletionPolicyForceDelete deletes remaining user resources before the managed resources are deleted.
+	DeletionPolicyForceDelete DeletionPolicy = "ForceDelete"
+)
+
+// opencontrolplane-gen:replace Foo=KIND
+// FooStatus defines the observed state of Foo.
+// opencontrolplane-gen:replace Foo=KIND
+type FooStatus struct {
+	commonapi.Status `json:",inline"`
+
+	// installedVersion is the version of the domain service that is installed on the MCP cluster.
+	// It is updated once an installation or upgrade has completed.
+	// +optional
+	InstalledVersion string `json:"installedVersion,omitempty"`
+
+	// deletionPolicy is the deletion policy that is applied while the resource is being deleted.
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// blockingResources lists the first user resources in the MCP cluster that block the deletion.
+	// +optional
+	BlockingResources []ResourceReference `json:"blockingResources,omitempty"`
+
+	// inventory lists the objects that have been applied to the MCP and workload cluster.
+	// Objects that are no longer part of the inventory after a reconciliation are pruned.
+	// +optional
+	Inventory []InventoryEntry `json:"inventory,omitempty"`
+}
+
+// InventoryCluster identifies the cluster an inventory entry belongs to.
+type InventoryCluster string
+
+const (
+	// InventoryClusterMCP is the MCP cluster.
+	InventoryClusterMCP InventoryCluster = "MCP"
+	// InventoryClusterWorkload is the workload cluster.
+	InventoryClusterWorkload InventoryCluster = "Workload"
+)
+
+// InventoryEntry identifies an object that has been applied to a cluster.
+type InventoryEntry struct {
+	// cluster is the cluster the object has been applied to.
+	// +kubebuilder:validation:Enum=MCP;Workload
+	Cluster InventoryCluster `json:"cluster"`
+	// group of the object, empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+	// version of the object.
+	Version string `json:"version"`
+	// kind of the object.
+	Kind string `json:"kind"`
+	// namespace of the object, empty for cluster-scoped objects.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// name of the object.
+	Name string `json:"name"`
+	// uid of the object when it has been applied.
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+}
+
+// ResourceReference identifies a resource in another cluster.
+type ResourceReference struct {
+	// apiVersion of the resource.
+	APIVersion string `json:"apiVersion"`
+	// kind of the resource.
+	Kind string `json:"kind"`
+	// namespace of the resource, empty for cluster-scoped resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// name of the resource.
+	Name string `json:"name"`
+}
+
+// opencontrolplane-gen:replace Foo=KIND foo=KIND_LOWER
+// Foo is the Schema for the foos API
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=`.status.phase`,name="Phase",type=string
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:metadata:labels="openmcp.cloud/cluster=onboarding"
+// opencontrolplane-gen:replace Foo=KIND
+type Foo struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// opencontrolplane-gen:replace Foo=KIND
+	// spec defines the desired state of Foo
+	// +required
+	// opencontrolplane-gen:replace Foo=KIND
+	Spec FooSpec `json:"spec"`
+
+	// opencontrolplane-gen:replace Foo=KIND
+	// status defines the observed state of Foo
+	// +optional
+	// opencontrolplane-gen:replace Foo=KIND
+	Status FooStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// opencontrolplane-gen:replace Foo=KIND
+// FooList contains a list of Foo
+// opencontrolplane-gen:replace Foo=KIND
+type FooList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// opencontrolplane-gen:replace Foo=KIND
+	Items []Foo `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		// opencontrolplane-gen:replace Foo=KIND
+		s.AddKnownTypes(GroupVersion, &Foo{}, &FooList{})
+		return nil
+	})
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package v1beta1
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// opencontrolplane-gen:replace Foo=KIND
+var _ conversion.Convertible = &Foo{}
+
+// opencontrolplane-gen:replace Foo=KIND
+// ConvertTo converts this Foo to the hub version.
+// opencontrolplane-gen:replace Foo=KIND
+func (src *Foo) ConvertTo(dstRaw conversion.Hub) error {
+	// opencontrolplane-gen:replace Foo=KIND
+	dst, ok := dstRaw.(*v1alpha1.Foo)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", dstRaw)
+	}
+	dst.ObjectMeta = src.ObjectMeta
+
+	// opencontrolplane-gen:replace Foo=KIND
+	dst.Spec.Foo = src.Spec.Foo
+	dst.Spec.Version = src.Spec.Version
+	dst.Spec.DeletionPolicy = v1alpha1.DeletionPolicy(src.Spec.Deletion.Policy)
+	dst.Spec.ForceDeleteGracePeriod = src.Spec.Deletion.ForceGracePeriod
+
+	dst.Status.Status = src.Status.Status
+	dst.Status.InstalledVersion = src.Status.InstalledVersion
+	dst.Status.DeletionPolicy = v1alpha1.DeletionPolicy(src.Status.DeletionPolicy)
+	dst.Status.BlockingResources = nil
+	for _, ref := range src.Status.BlockingResources {
+		dst.Status.BlockingResources = append(dst.Status.BlockingResources, v1alpha1.ResourceReference(ref))
+	}
+	dst.Status.Inventory = nil
+	for _, entry := range src.Status.Inventory {
+		dst.Status.Inventory = append(dst.Status.Inventory, v1alpha1.InventoryEntry{
+			Cluster:   v1alpha1.InventoryCluster(entry.Cluster),
+			Group:     entry.Group,
+			Version:   entry.Version,
+			Kind:      entry.Kind,
+			Namespace: entry.Namespace,
+			Name:      entry.Name,
+			UID:       entry.UID,
+		})
+	}
+	return nil
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// ConvertFrom converts the hub version to this Foo.
+// opencontrolplane-gen:replace Foo=KIND
+func (dst *Foo) ConvertFrom(srcRaw conversion.Hub) error {
+	// opencontrolplane-gen:replace Foo=KIND
+	src, ok := srcRaw.(*v1alpha1.Foo)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", srcRaw)
+	}
+	dst.ObjectMeta = src.ObjectMeta
+
+	// opencontrolplane-gen:replace Foo=KIND
+	dst.Spec.Foo = src.Spec.Foo
+	dst.Spec.Version = src.Spec.Version
+	dst.Spec.Deletion = DeletionSpec{
+		Policy:           DeletionPolicy(src.Spec.DeletionPolicy),
+		ForceGracePeriod: src.Spec.ForceDeleteGracePeriod,
+	}
+
+	dst.Status.Status = src.Status.Status
+	dst.Status.InstalledVersion = src.Status.InstalledVersion
+	dst.Status.DeletionPolicy = DeletionPolicy(src.Status.DeletionPolicy)
+	dst.Status.BlockingResources = nil
+	for _, ref := range src.Status.BlockingResources {
+		dst.Status.BlockingResources = append(dst.Status.BlockingResources, ResourceReference(ref))
+	}
+	dst.Status.Inventory = nil
+	for _, entry := range src.Status.Inventory {
+		dst.Status.Inventory = append(dst.Status.Inventory, InventoryEntry{
+			Cluster:   InventoryCluster(entry.Cluster),
+			Group:     entry.Group,
+			Version:   entry.Version,
+			Kind:      entry.Kind,
+			Namespace: entry.Namespace,
+			Name:      entry.Name,
+			UID:       entry.UID,
+		})
+	}
+	return nil
+}
+
+var _ conversion.Convertible = &ProviderConfig{}
+
+// ConvertTo converts this ProviderConfig to the hub version.
+func (src *ProviderConfig) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.ProviderConfig)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", dstRaw)
+	}
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha1.ProviderConfigSpec(src.Spec)
+	dst.Status = v1alpha1.ProviderConfigStatus(src.Status)
+	return nil
+}
+
+// ConvertFrom converts the hub version to this ProviderConfig.
+func (dst *ProviderConfig) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.ProviderConfig)
+	if !ok {
+		return fmt.Errorf("unsupported hub type %T", srcRaw)
+	}
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = ProviderConfigSpec(src.Spec)
+	dst.Status = ProviderConfigStatus(src.Status)
+	return nil
+}
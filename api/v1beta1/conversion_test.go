@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/randfill"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// seeds are the corpus of the round-trip fuzz tests that is also run by go test without -fuzz.
+var seeds = []int64{0, 1, 2, 3, 5, 8, 13, 21, 34, 55, 89, 144}
+
+// opencontrolplane-gen:replace Foo=KIND
+func FuzzFooRoundTrip(f *testing.F) {
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		// opencontrolplane-gen:replace Foo=KIND
+		roundTripSpoke(t, seed, &Foo{}, &Foo{}, &v1alpha1.Foo{})
+		// opencontrolplane-gen:replace Foo=KIND
+		roundTripHub(t, seed, &v1alpha1.Foo{}, &v1alpha1.Foo{}, &Foo{})
+	})
+}
+
+func FuzzProviderConfigRoundTrip(f *testing.F) {
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed int64) {
+		roundTripSpoke(t, seed, &ProviderConfig{}, &ProviderConfig{}, &v1alpha1.ProviderConfig{})
+		roundTripHub(t, seed, &v1alpha1.ProviderConfig{}, &v1alpha1.ProviderConfig{}, &ProviderConfig{})
+	})
+}
+
+// roundTripSpoke fills the spoke object randomly, converts it to the hub and back and expects no field to be lost.
+func roundTripSpoke(t *testing.T, seed int64, original, result conversion.Convertible, hub conversion.Hub) {
+	t.Helper()
+	fill(seed, original)
+	if err := original.ConvertTo(hub); err != nil {
+		t.Fatalf("conversion to hub failed: %v", err)
+	}
+	if err := result.ConvertFrom(hub); err != nil {
+		t.Fatalf("conversion from hub failed: %v", err)
+	}
+	if !equality.Semantic.DeepEqual(original, result) {
+		t.Errorf("fields lost in round trip via hub\nexpected: %+v\nactual:   %+v", original, result)
+	}
+}
+
+// roundTripHub fills the hub object randomly, converts it to the spoke and back and expects no field to be lost.
+func roundTripHub(t *testing.T, seed int64, original, result conversion.Hub, spoke conversion.Convertible) {
+	t.Helper()
+	fill(seed, original)
+	if err := spoke.ConvertFrom(original); err != nil {
+		t.Fatalf("conversion from hub failed: %v", err)
+	}
+	if err := spoke.ConvertTo(result); err != nil {
+		t.Fatalf("conversion to hub failed: %v", err)
+	}
+	if !equality.Semantic.DeepEqual(original, result) {
+		t.Errorf("fields lost in round trip via spoke\nexpected: %+v\nactual:   %+v", original, result)
+	}
+}
+
+// fill sets random values for all fields of obj except the TypeMeta, which is set by the API server and not converted.
+func fill(seed int64, obj any) {
+	randfill.NewWithSeed(seed).NilChance(0.2).NumElements(0, 3).Funcs(
+		func(tm *metav1.TypeMeta, _ randfill.Continue) {
+			*tm = metav1.TypeMeta{}
+		},
+	).Fill(obj)
+}
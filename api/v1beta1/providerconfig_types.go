@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	commonapi "github.com/openmcp-project/openmcp-operator/api/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ProviderConfigSpec defines the desired state of ProviderConfig
+type ProviderConfigSpec struct {
+	// pollInterval is the interval in which service objects are reconciled again
+	// to detect and correct drift of the managed resources.
+	// +optional
+	// +kubebuilder:default:="1m"
+	// +kubebuilder:validation:Format=duration
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+
+	// imagePullSecrets references secrets in the namespace of the service provider pod
+	// that are required to pull the images of the managed service.
+	// +optional
+	ImagePullSecrets []commonapi.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// forceConflicts takes over the ownership of fields of managed resources that are owned by other field managers,
+	// e.g. another controller or kubectl edit. If not set, conflicting fields are not applied and the conflict is reported
+	// in the status and the events of the service objects.
+	// +optional
+	ForceConflicts bool `json:"forceConflicts,omitempty"`
+
+	// versions lists the versions of the domain service that may be installed on the MCP clusters, e.g. v1.1.0.
+	// All versions known to the service provider are available if not set.
+	// +listType=set
+	// +kubebuilder:validation:items:Pattern=`^v[0-9]+\.[0-9]+\.[0-9]+$`
+	// +optional
+	Versions []string `json:"versions,omitempty"`
+
+	// defaultVersion is the version of the domain service that is installed for service objects that do not request a version.
+	// The latest available version is installed if not set.
+	// +kubebuilder:validation:Pattern=`^v[0-9]+\.[0-9]+\.[0-9]+$`
+	// +optional
+	DefaultVersion string `json:"defaultVersion,omitempty"`
+
+	// serviceControllerImage is the image of the domain service controller that is deployed to the workload cluster
+	// for every service object. The installed version of the domain service is used as tag if the image has neither a tag nor a digest.
+	// No controller is deployed if not set.
+	// +optional
+	ServiceControllerImage string `json:"serviceControllerImage,omitempty"`
+}
+
+// ProviderConfigStatus defines the observed state of ProviderConfig.
+type ProviderConfigStatus struct {
+	// conditions represent the current state of the ProviderConfig resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	//
+	// Standard condition types include:
+	// - "Available": the resource is fully functional
+	// - "Progressing": the resource is being created or updated
+	// - "Degraded": the resource failed to reach or maintain its desired state
+	//
+	// The status of each condition is one of True, False, or Unknown.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// observedGeneration is the generation of the ProviderConfig that was last validated.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// usedBy lists the first service objects that are currently reconciled with this ProviderConfig, sorted by namespace and name.
+	// +optional
+	UsedBy []commonapi.ObjectReference `json:"usedBy,omitempty"`
+
+	// usedByCount is the number of service objects that are currently reconciled with this ProviderConfig.
+	// +optional
+	UsedByCount int32 `json:"usedByCount,omitempty"`
+}
+
+// ProviderConfig is the Schema for the providerconfigs API
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:metadata:labels="openmcp.cloud/cluster=platform"
+type ProviderConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the desired state of ProviderConfig
+	// +required
+	Spec ProviderConfigSpec `json:"spec"`
+
+	// status defines the observed state of ProviderConfig
+	// +optional
+	Status ProviderConfigStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProviderConfigList contains a list of ProviderConfig
+type ProviderConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProviderConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(GroupVersion, &ProviderConfig{}, &ProviderConfigList{})
+		return nil
+	})
+}
@@ -0,0 +1,307 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/openmcp-project/controller-utils/pkg/clusters"
+	crdutil "github.com/openmcp-project/controller-utils/pkg/crds"
+	"github.com/openmcp-project/controller-utils/pkg/logging"
+	clustersv1alpha1 "github.com/openmcp-project/openmcp-operator/api/clusters/v1alpha1"
+	openmcpconst "github.com/openmcp-project/openmcp-operator/api/constants"
+	providerv1alpha1 "github.com/openmcp-project/openmcp-operator/api/provider/v1alpha1"
+	"github.com/openmcp-project/openmcp-operator/lib/clusteraccess"
+	"github.com/openmcp-project/openmcp-operator/lib/utils"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/api/crds"
+	// opencontrolplane-gen:replace foo=KIND_LOWER github.com/openmcp-project/service-provider-template=MODULE
+	foosv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/cabundle"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/steps"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/storageversion"
+)
+
+// initOptions configure the init command.
+type initOptions struct {
+	providerName           string
+	caBundle               *cabundle.Bundle
+	storageMigrationDryRun bool
+	log                    *logging.Logger
+}
+
+// runInit installs the service provider in idempotent steps: it requests access to the onboarding cluster,
+// creates or updates the CRDs, migrates existing objects to the storage version of the CRDs and registers
+// the service object kind at the ServiceProvider resource. Failed steps are retried, the sequence stops
+// at the first step that keeps failing. The result of each step is returned for the summary.
+func runInit(ctx context.Context, accessManager clusteraccess.Manager, platformCluster *clusters.Cluster, opts initOptions) ([]steps.Result, error) {
+	var onboardingCluster *clusters.Cluster
+	initSteps := []steps.Step{
+		{
+			Name: "RequestOnboardingAccess",
+			// the access manager waits for the access request to be granted on its own
+			NoRetry: true,
+			Run: func(ctx context.Context) ([]string, error) {
+				cluster, err := requestOnboardingClusterAccess(ctx, accessManager, platformCluster, opts.caBundle, initPermissions(), "init")
+				if err != nil {
+					return nil, err
+				}
+				onboardingCluster = cluster
+				return nil, nil
+			},
+		},
+		{
+			Name: "CreateOrUpdateCRDs",
+			Run: func(ctx context.Context) ([]string, error) {
+				return createOrUpdateCRDs(ctx, platformCluster, onboardingCluster, opts.log)
+			},
+		},
+		{
+			Name: "MigrateStorageVersions",
+			Run: func(ctx context.Context) ([]string, error) {
+				return migrateStorageVersions(ctx, platformCluster, onboardingCluster, opts.storageMigrationDryRun)
+			},
+		},
+		{
+			Name: "RegisterGVKs",
+			Run: func(ctx context.Context) ([]string, error) {
+				return registerGVKsAtServiceProvider(ctx, platformCluster.Client(), opts.providerName)
+			},
+		},
+	}
+	return (&steps.Runner{}).Run(ctx, initSteps)
+}
+
+// initPermissions are the permissions of the init command on the onboarding cluster.
+func initPermissions() []clustersv1alpha1.PermissionsRequest {
+	return []clustersv1alpha1.PermissionsRequest{
+		{
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{apiextensionv1.GroupName},
+					Resources: []string{"customresourcedefinitions", "customresourcedefinitions/status"},
+					Verbs:     []string{"*"},
+				},
+				{
+					// opencontrolplane-gen:replace foo=KIND_LOWER
+					APIGroups: []string{foosv1alpha1.GroupVersion.Group},
+					Resources: []string{"*"},
+					Verbs:     []string{"get", "list", "patch"},
+				},
+			},
+		},
+	}
+}
+
+// logInitSummary logs the outcome and the changes of every init step.
+func logInitSummary(results []steps.Result) {
+	for _, res := range results {
+		kv := []any{"step", res.Name, "status", res.Status, "attempts", res.Attempts, "duration", res.Duration.String()}
+		if res.Err != nil {
+			kv = append(kv, "error", res.Err.Error())
+		}
+		setupLog.Info("Init step summary", kv...)
+		for _, change := range res.Changes {
+			setupLog.Info("Init change", "step", res.Name, "change", change)
+		}
+	}
+}
+
+// crdCluster returns the cluster a CRD of the service provider belongs to according to its cluster label.
+func crdCluster(crd *apiextensionv1.CustomResourceDefinition, platformCluster, onboardingCluster *clusters.Cluster) (*clusters.Cluster, error) {
+	var cluster *clusters.Cluster
+	switch crd.Labels[openmcpconst.ClusterLabel] {
+	case clustersv1alpha1.PURPOSE_PLATFORM:
+		cluster = platformCluster
+	case clustersv1alpha1.PURPOSE_ONBOARDING:
+		cluster = onboardingCluster
+	}
+	if cluster == nil {
+		return nil, fmt.Errorf("no cluster for CRD %s with label %s=%s", crd.Name, openmcpconst.ClusterLabel, crd.Labels[openmcpconst.ClusterLabel])
+	}
+	return cluster, nil
+}
+
+// createOrUpdateCRDs creates or updates the CRDs of the service provider on the platform and onboarding cluster.
+// The resource versions of the CRDs before and after are compared to report which CRDs have been changed.
+func createOrUpdateCRDs(ctx context.Context, platformCluster, onboardingCluster *clusters.Cluster, log *logging.Logger) ([]string, error) {
+	before, err := crdResourceVersions(ctx, platformCluster, onboardingCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	crdManager := crdutil.NewCRDManager(openmcpconst.ClusterLabel, func() ([]*apiextensionv1.CustomResourceDefinition, error) {
+		return desiredCRDs(ctx, platformCluster, onboardingCluster)
+	})
+	crdManager.AddCRDLabelToClusterMapping(clustersv1alpha1.PURPOSE_PLATFORM, platformCluster)
+	crdManager.AddCRDLabelToClusterMapping(clustersv1alpha1.PURPOSE_ONBOARDING, onboardingCluster)
+	if err := crdManager.CreateOrUpdateCRDs(ctx, log); err != nil {
+		return nil, err
+	}
+
+	after, err := crdResourceVersions(ctx, platformCluster, onboardingCluster)
+	if err != nil {
+		return nil, err
+	}
+	var changes []string
+	for _, key := range slices.Sorted(maps.Keys(after)) {
+		old, ok := before[key]
+		switch {
+		case !ok:
+			changes = append(changes, "created CRD "+key)
+		case old != after[key]:
+			changes = append(changes, "updated CRD "+key)
+		}
+	}
+	return changes, nil
+}
+
+// desiredCRDs returns the CRDs of the service provider with the conversion of the existing CRDs.
+// The conversion webhook is configured when the service provider runs, e.g. with --webhook-self-signed, or externally,
+// so it is kept when the CRDs are updated. As long as no conversion webhook is configured, only the storage version
+// is served, since the API server cannot convert between versions with different schemas on its own.
+func desiredCRDs(ctx context.Context, platformCluster, onboardingCluster *clusters.Cluster) ([]*apiextensionv1.CustomResourceDefinition, error) {
+	crdList, err := crds.CRDs()
+	if err != nil {
+		return nil, err
+	}
+	for _, crd := range crdList {
+		cluster, err := crdCluster(crd, platformCluster, onboardingCluster)
+		if err != nil {
+			return nil, err
+		}
+		existing := &apiextensionv1.CustomResourceDefinition{}
+		if err := cluster.Client().Get(ctx, client.ObjectKey{Name: crd.Name}, existing); client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("unable to get CRD %s from %s cluster: %w", crd.Name, cluster.ID(), err)
+		}
+		if !hasConversionWebhook(crd) {
+			crd.Spec.Conversion = existing.Spec.Conversion
+		}
+		if hasConversionWebhook(crd) {
+			continue
+		}
+		for i := range crd.Spec.Versions {
+			crd.Spec.Versions[i].Served = crd.Spec.Versions[i].Storage
+		}
+	}
+	return crdList, nil
+}
+
+// hasConversionWebhook returns true if the CRD converts between its versions via a webhook.
+func hasConversionWebhook(crd *apiextensionv1.CustomResourceDefinition) bool {
+	return crd.Spec.Conversion != nil && crd.Spec.Conversion.Strategy == apiextensionv1.WebhookConverter
+}
+
+// crdResourceVersions returns the resource versions of the existing CRDs of the service provider by cluster and name.
+func crdResourceVersions(ctx context.Context, platformCluster, onboardingCluster *clusters.Cluster) (map[string]string, error) {
+	crdList, err := crds.CRDs()
+	if err != nil {
+		return nil, err
+	}
+	versions := map[string]string{}
+	for _, crd := range crdList {
+		cluster, err := crdCluster(crd, platformCluster, onboardingCluster)
+		if err != nil {
+			return nil, err
+		}
+		existing := &apiextensionv1.CustomResourceDefinition{}
+		if err := cluster.Client().Get(ctx, client.ObjectKey{Name: crd.Name}, existing); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return nil, fmt.Errorf("unable to get CRD %s from %s cluster: %w", crd.Name, cluster.ID(), err)
+			}
+			continue
+		}
+		versions[cluster.ID()+"/"+crd.Name] = existing.ResourceVersion
+	}
+	return versions, nil
+}
+
+// migrateStorageVersions rewrites the objects of all CRDs of the service provider in their storage version
+// and prunes old versions from the stored versions of the CRDs.
+// The CRDs are looked up on the platform or onboarding cluster according to their cluster label.
+func migrateStorageVersions(ctx context.Context, platformCluster, onboardingCluster *clusters.Cluster, dryRun bool) ([]string, error) {
+	crdList, err := crds.CRDs()
+	if err != nil {
+		return nil, err
+	}
+	var changes []string
+	for _, crd := range crdList {
+		cluster, err := crdCluster(crd, platformCluster, onboardingCluster)
+		if err != nil {
+			return nil, err
+		}
+		migrator := &storageversion.Migrator{Client: cluster.Client(), DryRun: dryRun}
+		res, err := migrator.Migrate(ctx, crd.Name)
+		if err != nil {
+			return nil, fmt.Errorf("%s cluster: %w", cluster.ID(), err)
+		}
+		setupLog.Info("Storage version migration finished", "crd", res.CRD, "cluster", cluster.ID(), "storageVersion", res.StorageVersion,
+			"objects", res.Migrated, "prunedVersions", res.PrunedVersions, "dryRun", dryRun)
+		changes = append(changes, migrationChange(cluster.ID(), res, dryRun))
+	}
+	return changes, nil
+}
+
+// migrationChange describes the result of the storage version migration of a CRD, including the number of migrated objects.
+func migrationChange(clusterID string, res storageversion.Result, dryRun bool) string {
+	switch {
+	case len(res.PrunedVersions) == 0:
+		return fmt.Sprintf("CRD %s/%s: 0 objects migrated, all objects are stored in %s", clusterID, res.CRD, res.StorageVersion)
+	case dryRun:
+		return fmt.Sprintf("CRD %s/%s: %d objects would be migrated to %s and stored versions %v would be pruned",
+			clusterID, res.CRD, res.Migrated, res.StorageVersion, res.PrunedVersions)
+	default:
+		return fmt.Sprintf("CRD %s/%s: %d objects migrated to %s and stored versions %v pruned",
+			clusterID, res.CRD, res.Migrated, res.StorageVersion, res.PrunedVersions)
+	}
+}
+
+// registerGVKsAtServiceProvider registers the GVK of the service object kind at the ServiceProvider resource
+// unless it is registered already.
+func registerGVKsAtServiceProvider(ctx context.Context, c client.Client, providerName string) ([]string, error) {
+	spGVK := metav1.GroupVersionKind{
+		// opencontrolplane-gen:replace foo=KIND_LOWER
+		Group: foosv1alpha1.GroupVersion.Group,
+		// opencontrolplane-gen:replace foo=KIND_LOWER
+		Version: foosv1alpha1.GroupVersion.Version,
+		// opencontrolplane-gen:replace Foo=KIND
+		Kind: "Foo",
+	}
+	sp := &providerv1alpha1.ServiceProvider{}
+	if err := c.Get(ctx, client.ObjectKey{Name: providerName}, sp); err != nil {
+		return nil, fmt.Errorf("unable to get ServiceProvider %s: %w", providerName, err)
+	}
+	if slices.Contains(sp.Status.Resources, spGVK) {
+		return nil, nil
+	}
+	if err := utils.RegisterGVKsAtServiceProvider(ctx, c, providerName, spGVK); err != nil {
+		return nil, err
+	}
+	return []string{fmt.Sprintf("registered %s.%s/%s at ServiceProvider %s", spGVK.Kind, spGVK.Group, spGVK.Version, providerName)}, nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/storageversion"
+)
+
+func TestMigrationChange(t *testing.T) {
+	migrated := storageversion.Result{CRD: "foos.example.com", StorageVersion: "v1alpha1", Migrated: 3, PrunedVersions: []string{"v1beta1"}}
+	tests := []struct {
+		name   string
+		res    storageversion.Result
+		dryRun bool
+		want   string
+	}{
+		{
+			name: "nothing to migrate",
+			res:  storageversion.Result{CRD: "foos.example.com", StorageVersion: "v1alpha1"},
+			want: "CRD onboarding/foos.example.com: 0 objects migrated, all objects are stored in v1alpha1",
+		},
+		{
+			name: "migrated",
+			res:  migrated,
+			want: "CRD onboarding/foos.example.com: 3 objects migrated to v1alpha1 and stored versions [v1beta1] pruned",
+		},
+		{
+			name:   "dry run",
+			res:    migrated,
+			dryRun: true,
+			want:   "CRD onboarding/foos.example.com: 3 objects would be migrated to v1alpha1 and stored versions [v1beta1] would be pruned",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := migrationChange("onboarding", tt.res, tt.dryRun); got != tt.want {
+				t.Errorf("migrationChange() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
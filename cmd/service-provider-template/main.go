@@ -21,7 +21,11 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"maps"
+	"math"
+	"net/url"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -30,7 +34,6 @@ import (
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	"github.com/openmcp-project/controller-utils/pkg/clusters"
-	crdutil "github.com/openmcp-project/controller-utils/pkg/crds"
 	"github.com/openmcp-project/controller-utils/pkg/logging"
 	"github.com/openmcp-project/opencontrolplane-runtime/pkg/serviceprovider"
 	localaccess "github.com/openmcp-project/opencontrolplane-runtime/pkg/serviceprovider/clusteraccess"
@@ -42,18 +45,24 @@ import (
 	"github.com/openmcp-project/openmcp-operator/lib/clusteraccess/advanced"
 	"github.com/openmcp-project/openmcp-operator/lib/utils"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
@@ -61,8 +70,22 @@ import (
 	"github.com/openmcp-project/service-provider-template/internal/controller"
 	// opencontrolplane-gen:replace foo=KIND_LOWER github.com/openmcp-project/service-provider-template=MODULE
 	foosv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+	// opencontrolplane-gen:replace foo=KIND_LOWER github.com/openmcp-project/service-provider-template=MODULE
+	foosv1beta1 "github.com/openmcp-project/service-provider-template/api/v1beta1"
 	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
 	"github.com/openmcp-project/service-provider-template/api/crds"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	webhookv1alpha1 "github.com/openmcp-project/service-provider-template/internal/webhook/v1alpha1"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/cabundle"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/health"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/metrics"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/tracing"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/webhook/selfsigned"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -91,6 +114,8 @@ func initPlatformScheme() {
 	utilruntime.Must(apiextensionv1.AddToScheme(platformScheme))
 	// opencontrolplane-gen:replace foo=KIND_LOWER
 	utilruntime.Must(foosv1alpha1.AddToScheme(platformScheme))
+	// opencontrolplane-gen:replace foo=KIND_LOWER
+	utilruntime.Must(foosv1beta1.AddToScheme(platformScheme))
 	utilruntime.Must(clustersv1alpha1.AddToScheme(platformScheme))
 	utilruntime.Must(providerv1alpha1.AddToScheme(platformScheme))
 }
@@ -100,6 +125,8 @@ func initOnboardingScheme() {
 	utilruntime.Must(apiextensionv1.AddToScheme(onboardingScheme))
 	// opencontrolplane-gen:replace foo=KIND_LOWER
 	utilruntime.Must(foosv1alpha1.AddToScheme(onboardingScheme))
+	// opencontrolplane-gen:replace foo=KIND_LOWER
+	utilruntime.Must(foosv1beta1.AddToScheme(onboardingScheme))
 }
 
 func initMcpScheme() {
@@ -116,6 +143,16 @@ func initWorkloadScheme() {
 
 const (
 	debugEnvVar = "DEV_DEBUG"
+	// mcpClusterID identifies the access to the MCP cluster of a service object.
+	mcpClusterID = "mcp"
+	// opencontrolplane-gen:if WORKLOADCLUSTER=true
+	// workloadClusterID identifies the access to the workload cluster of a service object.
+	workloadClusterID = "workload"
+	// serviceControllerMCPClusterID identifies the access to the MCP cluster of a service object that is handed to its domain service controller.
+	serviceControllerMCPClusterID = "mcp-service-controller"
+	// opencontrolplane-gen:fi
+	// webhookServicePort is the port of the service that exposes the webhook server.
+	webhookServicePort = 443
 )
 
 // nolint:gocyclo
@@ -125,6 +162,14 @@ func main() {
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
+	var enableWebhooks, webhookSelfSigned bool
+	var storageMigrationDryRun bool
+	var uninstallDrain bool
+	var uninstallDrainTimeout time.Duration
+	var livenessReconcileTimeout time.Duration
+	var tracingOptions tracing.Options
+	var webhookServiceName, webhookURL string
+	var caBundleSource cabundle.Source
 	var enableLeaderElection bool
 	var probeAddr string
 	var secureMetrics bool
@@ -143,6 +188,35 @@ func main() {
 	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
 	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
 	flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false, "If set, the validating, defaulting and conversion webhooks are served by the webhook server.")
+	flag.BoolVar(&webhookSelfSigned, "webhook-self-signed", false,
+		"If set, a certificate signed by a self-signed CA is generated for the webhook server and the webhook configurations and CRD conversion webhooks are registered with the CA bundle. "+
+			"The CA is shared by all replicas via the Secret <webhook-service-name>-ca in the pod namespace. "+
+			"Use this if cert-manager is not available.")
+	flag.StringVar(&webhookServiceName, "webhook-service-name", "",
+		"The name of the service in the pod namespace that exposes the webhook server. Required for --webhook-self-signed.")
+	flag.StringVar(&webhookURL, "webhook-url", "",
+		"The base URL under which the webhook server is reachable from the onboarding cluster. Required for --webhook-self-signed.")
+	flag.StringVar(&caBundleSource.ConfigMapName, "ca-bundle-configmap", "",
+		"Name of a ConfigMap in the pod namespace with a CA bundle that is trusted in addition for all cluster clients.")
+	flag.StringVar(&caBundleSource.SecretName, "ca-bundle-secret", "",
+		"Name of a Secret in the pod namespace with a CA bundle that is trusted in addition for all cluster clients.")
+	flag.StringVar(&caBundleSource.Key, "ca-bundle-key", cabundle.DefaultKey, "The key of the CA bundle in the ConfigMap or Secret.")
+	flag.BoolVar(&storageMigrationDryRun, "storage-migration-dry-run", false,
+		"If set, the init command only reports which objects would be migrated to the storage version of the CRDs and which stored versions would be pruned.")
+	flag.BoolVar(&uninstallDrain, "uninstall-drain", false,
+		"If set, the uninstall command deletes the remaining service objects and waits until they are gone instead of refusing to uninstall.")
+	flag.DurationVar(&uninstallDrainTimeout, "uninstall-drain-timeout", 10*time.Minute,
+		"The time the uninstall command waits for the drained service objects and for the CRDs to be deleted.")
+	flag.DurationVar(&livenessReconcileTimeout, "liveness-reconcile-timeout", 15*time.Minute,
+		"The duration after which a running reconcile, or queued service objects without a completed reconcile, are considered to be stuck and the liveness probe fails.")
+	flag.StringVar(&tracingOptions.Exporter, "tracing-exporter", tracing.ExporterNone,
+		"The exporter of the OpenTelemetry traces, one of none, otlp, stdout or file.")
+	flag.StringVar(&tracingOptions.OTLPEndpoint, "tracing-otlp-endpoint", "",
+		"The host and port of the OTLP gRPC receiver. If not set, OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4317 is used.")
+	flag.BoolVar(&tracingOptions.OTLPInsecure, "tracing-otlp-insecure", false, "If set, TLS is disabled for the connection to the OTLP receiver.")
+	flag.StringVar(&tracingOptions.File, "tracing-file", "", "The file the traces are written to by the file exporter.")
+	flag.Float64Var(&tracingOptions.SampleRatio, "tracing-sample-ratio", 1, "The ratio of traces that are sampled, between 0 and 1.")
 	flag.StringVar(&metricsCertPath, "metrics-cert-path", "",
 		"The directory that contains the metrics server certificate.")
 	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
@@ -154,7 +228,7 @@ func main() {
 
 	// extract command from os.Args if present to allow further flag parsing
 	if len(os.Args) > 1 {
-		command = os.Args[1] // either init or run
+		command = os.Args[1] // either init, plan, uninstall or run
 		os.Args = append([]string{os.Args[0]}, os.Args[2:]...)
 	}
 
@@ -181,6 +255,30 @@ func main() {
 		TLSOpts: webhookTLSOpts,
 	}
 
+	if enableWebhooks && webhookSelfSigned {
+		if len(webhookServiceName) == 0 {
+			setupLog.Error(fmt.Errorf("--webhook-service-name is required"), "unable to generate self-signed webhook certificate")
+			os.Exit(1)
+		}
+		// the onboarding cluster cannot reach the service of the webhook server in the platform cluster
+		if len(webhookURL) == 0 {
+			setupLog.Error(fmt.Errorf("--webhook-url is required"), "unable to register the webhooks on the onboarding cluster")
+			os.Exit(1)
+		}
+		if _, err := url.Parse(webhookURL); err != nil {
+			setupLog.Error(err, "invalid webhook url")
+			os.Exit(1)
+		}
+		if len(webhookCertPath) == 0 {
+			dir, err := os.MkdirTemp("", "webhook-certs")
+			if err != nil {
+				setupLog.Error(err, "unable to create directory for self-signed webhook certificate")
+				os.Exit(1)
+			}
+			webhookCertPath = dir
+		}
+	}
+
 	if len(webhookCertPath) > 0 {
 		setupLog.Info("Initializing webhook certificate watcher using provided certificates",
 			"webhook-cert-path", webhookCertPath, "webhook-cert-name", webhookCertName, "webhook-cert-key", webhookCertKey)
@@ -244,57 +342,89 @@ func main() {
 		setupLog.Error(fmt.Errorf("environment variable %s not set - cannot determine source namespace for secrets", openmcpconst.EnvVariablePodNamespace), "pod namespace missing")
 		os.Exit(1)
 	}
+	ctx := context.Background()
+	caBundle := &cabundle.Bundle{}
+	if caBundleSource.Enabled() {
+		caBundleSource.Namespace = podNamespace
+		if err := caBundleSource.Validate(); err != nil {
+			setupLog.Error(err, "invalid CA bundle flags")
+			os.Exit(1)
+		}
+		data, err := caBundleSource.Load(ctx, platformCluster.Client())
+		if err != nil {
+			setupLog.Error(err, "Failed to load CA bundle")
+			os.Exit(1)
+		}
+		caBundle.Set(data)
+		if platformCluster, err = withCABundle(platformCluster, platformScheme, data); err != nil {
+			setupLog.Error(err, "Failed to add CA bundle to platform cluster")
+			os.Exit(1)
+		}
+	}
 	clusterAccessManager := clusteraccess.NewClusterAccessManager(platformCluster.Client(),
 		// opencontrolplane-gen:replace foo=KIND_LOWER
 		foosv1alpha1.GroupVersion.Group, os.Getenv("POD_NAMESPACE"))
 	clusterAccessManager.WithLogger(&log).
 		WithInterval(10 * time.Second).
 		WithTimeout(30 * time.Minute)
-	ctx := context.Background()
 	// init (job that installs CRDs)
 	if command == "init" {
-		initPermissions := []clustersv1alpha1.PermissionsRequest{
-			{
-
-				Rules: []rbacv1.PolicyRule{
-					{
-						APIGroups: []string{"apiextensions.k8s.io"},
-						Resources: []string{"customresourcedefinitions"},
-						Verbs:     []string{"*"},
-					},
-				},
-			},
-		}
-		onboardingCluster, err := requestOnboardingClusterAccess(ctx, clusterAccessManager, platformCluster, initPermissions, "init")
+		results, err := runInit(ctx, clusterAccessManager, platformCluster, initOptions{
+			providerName:           providerName,
+			caBundle:               caBundle,
+			storageMigrationDryRun: storageMigrationDryRun,
+			log:                    &log,
+		})
+		logInitSummary(results)
 		if err != nil {
-			setupLog.Error(err, "Failed to create and wait for onboarding cluster access")
-		}
-
-		crdManager := crdutil.NewCRDManager(openmcpconst.ClusterLabel, crds.CRDs)
-
-		crdManager.AddCRDLabelToClusterMapping(clustersv1alpha1.PURPOSE_PLATFORM, platformCluster)
-		crdManager.AddCRDLabelToClusterMapping(clustersv1alpha1.PURPOSE_ONBOARDING, onboardingCluster)
-
-		if err := crdManager.CreateOrUpdateCRDs(ctx, &log); err != nil {
-			setupLog.Error(err, "Failed to create or update CRDs")
+			setupLog.Error(err, "Failed to initialize the service provider")
+			os.Exit(1)
 		}
-
-		spGVK := metav1.GroupVersionKind{
-			// opencontrolplane-gen:replace foo=KIND_LOWER
-			Group: foosv1alpha1.GroupVersion.Group,
-			// opencontrolplane-gen:replace foo=KIND_LOWER
-			Version: foosv1alpha1.GroupVersion.Version,
-			// opencontrolplane-gen:replace Foo=KIND
-			Kind: "Foo",
+		return
+	}
+	// plan (prints the changes this version would make on the MCP clusters without applying them)
+	if command == "plan" {
+		if err := runPlan(ctx, clusterAccessManager, platformCluster, planOptions{
+			providerName:    providerName,
+			podNamespace:    podNamespace,
+			caBundle:        caBundle,
+			caBundleEnabled: caBundleSource.Enabled(),
+		}, os.Stdout); err != nil {
+			setupLog.Error(err, "Failed to plan changes")
+			os.Exit(1)
 		}
-		if err := utils.RegisterGVKsAtServiceProvider(ctx, platformCluster.Client(), providerName, spGVK); err != nil {
-			setupLog.Error(err, "Failed to register GVK at ServiceProvider")
-			return
+		return
+	}
+	// uninstall (job that removes CRDs, the GVK registration and access requests)
+	if command == "uninstall" {
+		if err := runUninstall(ctx, clusterAccessManager, platformCluster, uninstallOptions{
+			providerName: providerName,
+			podNamespace: podNamespace,
+			caBundle:     caBundle,
+			drain:        uninstallDrain,
+			drainTimeout: uninstallDrainTimeout,
+		}); err != nil {
+			setupLog.Error(err, "Failed to uninstall")
+			os.Exit(1)
 		}
-
 		return
 	}
 	// run (sp controller deployment)
+	var webhookCABundle []byte
+	if enableWebhooks && webhookSelfSigned {
+		ca, err := selfsigned.LoadOrCreateCA(ctx, platformCluster.Client(), client.ObjectKey{Namespace: podNamespace, Name: webhookServiceName + "-ca"})
+		if err != nil {
+			setupLog.Error(err, "unable to load CA for self-signed webhook certificate")
+			os.Exit(1)
+		}
+		u, _ := url.Parse(webhookURL)
+		dnsNames := append(selfsigned.ServiceDNSNames(webhookServiceName, podNamespace), u.Hostname())
+		if err := ca.WriteServingCertificate(webhookCertPath, webhookCertName, webhookCertKey, dnsNames...); err != nil {
+			setupLog.Error(err, "unable to generate self-signed webhook certificate")
+			os.Exit(1)
+		}
+		webhookCABundle = ca.Bundle()
+	}
 	runPermissions := []clustersv1alpha1.PermissionsRequest{
 		{
 			Rules: []rbacv1.PolicyRule{
@@ -304,12 +434,34 @@ func main() {
 					Resources: []string{"*"},
 					Verbs:     []string{"*"},
 				},
+				{
+					// events on the service objects, recorded with the events API or the core API as fallback
+					APIGroups: []string{corev1.GroupName, eventsv1.GroupName},
+					Resources: []string{"events"},
+					Verbs:     []string{"create", "patch"},
+				},
 			},
 		},
 	}
-	onboardingCluster, err := requestOnboardingClusterAccess(ctx, clusterAccessManager, platformCluster, runPermissions, "run")
+	if enableWebhooks && webhookSelfSigned {
+		runPermissions[0].Rules = append(runPermissions[0].Rules, rbacv1.PolicyRule{
+			APIGroups: []string{admissionregistrationv1.GroupName},
+			Resources: []string{"validatingwebhookconfigurations", "mutatingwebhookconfigurations"},
+			Verbs:     []string{"get", "create", "update", "patch"},
+		}, rbacv1.PolicyRule{
+			APIGroups:     []string{apiextensionv1.GroupName},
+			Resources:     []string{"customresourcedefinitions"},
+			ResourceNames: []string{webhookv1alpha1.FooCRDName()},
+			Verbs:         []string{"get", "patch"},
+		})
+	}
+	signalCtx := ctrl.SetupSignalHandler()
+	onboardingCluster, err := waitForOnboardingClusterAccess(signalCtx, probeAddr, func(ctx context.Context) (*clusters.Cluster, error) {
+		return requestOnboardingClusterAccess(ctx, clusterAccessManager, platformCluster, caBundle, runPermissions, "run")
+	})
 	if err != nil {
 		setupLog.Error(err, "Failed to create and wait for onboarding cluster access")
+		os.Exit(1)
 	}
 	// end sp specifics
 
@@ -337,72 +489,212 @@ func main() {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
+	if tracingOptions.Enabled() {
+		tracingOptions.ServiceName = providerName
+		tracingProvider, err := tracing.Setup(signalCtx, tracingOptions)
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		if err := mgr.Add(tracingProvider); err != nil {
+			setupLog.Error(err, "unable to add tracing provider to manager")
+			os.Exit(1)
+		}
+	}
 	if err = mgr.Add(platformCluster.Cluster()); err != nil {
 		setupLog.Error(err, "unable to add platform cluster to manager")
 		os.Exit(1)
 	}
+	if caBundleSource.Enabled() {
+		if err = mgr.Add(&cabundle.Watcher{
+			Source: caBundleSource,
+			Client: platformCluster.Client(),
+			Bundle: caBundle,
+		}); err != nil {
+			setupLog.Error(err, "unable to add CA bundle watcher to manager")
+			os.Exit(1)
+		}
+	}
 
-	// TODO: define minimum set of permission the service provider requires on the mcp cluster
-	mcpTokenAccessConfig := &clustersv1alpha1.TokenConfig{
-		Permissions: []clustersv1alpha1.PermissionsRequest{
-			{
-				Rules: []rbacv1.PolicyRule{
-					{
-						APIGroups: []string{"*"},
-						Resources: []string{"*"},
-						Verbs:     []string{"*"},
-					},
-				},
-			},
-		},
-		RoleRefs: []common.RoleRef{
-			{
-				Name: "cluster-admin",
-				Kind: "ClusterRole",
+	clusterAccessReconciler, err := newClusterAccessReconciler(platformCluster, providerName, caBundle, caBundleSource.Enabled(), tracingOptions.Enabled())
+	if err != nil {
+		setupLog.Error(err, "unable to create cluster access reconciler")
+		os.Exit(1)
+	}
+
+	if err := metrics.RegisterObjectCollector(mgr.GetClient()); err != nil {
+		setupLog.Error(err, "unable to register metrics")
+		os.Exit(1)
+	}
+	watchdog := &health.Watchdog{
+		Timeout: livenessReconcileTimeout,
+		// the controller of the service objects is named after the service provider
+		Queued: health.WorkqueueDepth(ctrlmetrics.Registry, providerName),
+	}
+	// opencontrolplane-gen:replace foo=KIND_LOWER Foo=KIND
+	spr := serviceprovider.NewAPIReconcilerBuilder[*foosv1alpha1.Foo, *foosv1alpha1.ProviderConfig]().
+		// opencontrolplane-gen:replace foo=KIND_LOWER Foo=KIND
+		EmptyObjectProvider(func() *foosv1alpha1.Foo { return &foosv1alpha1.Foo{} }).
+		// opencontrolplane-gen:replace foo=KIND_LOWER Foo=KIND
+		EmptyConfigProvider(func() *foosv1alpha1.ProviderConfig { return &foosv1alpha1.ProviderConfig{} }).
+		PlatformCluster(platformCluster).
+		OnboardingCluster(onboardingCluster).
+		// opencontrolplane-gen:if SECRETWATCHER=true
+		SecretNamespace(podNamespace).
+		// opencontrolplane-gen:fi
+		// opencontrolplane-gen:replace Foo=KIND
+		Reconciler(&controller.FooReconciler{
+			OnboardingCluster: onboardingCluster,
+			PlatformCluster:   platformCluster,
+			PodNamespace:      podNamespace,
+			Recorder:          controller.NewDeduplicatingRecorder(mgr.GetEventRecorder(providerName), eventDeduplicationWindow),
+			FieldManager:      providerName,
+			Watchdog:          watchdog,
+			// opencontrolplane-gen:if WORKLOADCLUSTER=true
+			ServiceControllerAccess: func(ctx context.Context, req reconcile.Request) (*clusters.Cluster, error) {
+				return clusterAccessReconciler.Access(ctx, req, serviceControllerMCPClusterID)
 			},
-		},
+			// opencontrolplane-gen:fi
+		}).
+		AdvancedClusterAccessReconciler(clusterAccessReconciler).
+		// the operation annotations do not change the generation, so their updates have to pass the predicates explicitly
+		Predicates(predicate.Or(predicate.GenerationChangedPredicate{}, controller.OperationAnnotationChanged())).
+		MustBuild()
+	if err := spr.SetupWithManager(mgr, providerName); err != nil {
+		// opencontrolplane-gen:replace foo=PROVIDER_NAME
+		setupLog.Error(err, "unable to create controller", "controller", "foo")
+		os.Exit(1)
+	}
+	if err := (&controller.ProviderConfigReconciler{
+		PlatformCluster:   platformCluster,
+		OnboardingCluster: onboardingCluster,
+		PodNamespace:      podNamespace,
+		ProviderName:      providerName,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "providerconfig")
+		os.Exit(1)
+	}
+	if enableWebhooks {
+		// opencontrolplane-gen:replace Foo=KIND
+		if err := webhookv1alpha1.SetupFooWebhookWithManager(mgr); err != nil {
+			// opencontrolplane-gen:replace Foo=KIND
+			setupLog.Error(err, "unable to create webhook", "webhook", "Foo")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupProviderConfigWebhookWithManager(mgr, onboardingCluster.Client(), providerName); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ProviderConfig")
+			os.Exit(1)
+		}
+		if webhookSelfSigned {
+			if err := registerSelfSignedWebhooks(ctx, platformCluster, onboardingCluster, podNamespace, webhookServiceName, webhookURL, webhookCABundle); err != nil {
+				setupLog.Error(err, "unable to register webhook configurations")
+				os.Exit(1)
+			}
+		}
+	}
+	// +kubebuilder:scaffold:builder
+
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
+	if err := mgr.AddHealthzCheck("reconcile", watchdog.Check); err != nil {
+		setupLog.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
+	// the token is not refreshed while the service provider runs, a restart requests a new one
+	if err := mgr.AddHealthzCheck("onboarding-token", health.TokenValidity(onboardingCluster.RESTConfig(), onboardingTokenMinValidity)); err != nil {
+		setupLog.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
+	if err := addReadyzChecks(mgr, platformCluster, onboardingCluster); err != nil {
+		setupLog.Error(err, "unable to set up ready check")
+		os.Exit(1)
+	}
+
+	setupLog.Info("starting manager")
+	if err := mgr.Start(signalCtx); err != nil {
+		setupLog.Error(err, "problem running manager")
+		os.Exit(1)
+	}
+}
+
+// addReadyzChecks adds the readiness checks of the run command: the platform and onboarding cluster are reachable,
+// the CRDs are installed in the version of this service provider and the informer caches are synced.
+func addReadyzChecks(mgr ctrl.Manager, platformCluster, onboardingCluster *clusters.Cluster) error {
+	platformAPIServer, err := health.APIServer(platformCluster.RESTConfig())
+	if err != nil {
+		return err
 	}
-	mcpClusterRequest := advanced.ExistingClusterRequest("mcp", "mcp", func(req reconcile.Request, _ ...any) (*common.ObjectReference, error) {
-		namespace, err := utils.StableMCPNamespace(req.Name, req.Namespace)
+	onboardingAPIServer, err := health.APIServer(onboardingCluster.RESTConfig())
+	if err != nil {
+		return err
+	}
+	crdList, err := crds.CRDs()
+	if err != nil {
+		return err
+	}
+	var platformCRDs, onboardingCRDs []*apiextensionv1.CustomResourceDefinition
+	for _, crd := range crdList {
+		cluster, err := crdCluster(crd, platformCluster, onboardingCluster)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		return &common.ObjectReference{
-			Name:      req.Name,
-			Namespace: namespace,
-		}, nil
-	}).
+		if cluster == platformCluster {
+			platformCRDs = append(platformCRDs, crd)
+		} else {
+			onboardingCRDs = append(onboardingCRDs, crd)
+		}
+	}
+	checks := map[string]healthz.Checker{
+		"platform-cluster":         platformAPIServer,
+		"onboarding-cluster":       onboardingAPIServer,
+		"platform-crds":            health.CRDs(platformCluster.Client(), platformCRDs...),
+		"onboarding-crds":          health.CRDs(onboardingCluster.Client(), onboardingCRDs...),
+		"platform-informer-sync":   health.CacheSynced(platformCluster.Cluster().GetCache()),
+		"onboarding-informer-sync": health.CacheSynced(mgr.GetCache()),
+	}
+	for _, name := range slices.Sorted(maps.Keys(checks)) {
+		if err := mgr.AddReadyzCheck(name, checks[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newClusterAccessReconciler returns the reconciler that requests access to the MCP and workload clusters of the service objects.
+// If caBundleEnabled is set, the clients of these clusters trust the CA bundle in addition.
+// If tracingEnabled is set, the cluster access resolution and every request of these clients is traced.
+func newClusterAccessReconciler(platformCluster *clusters.Cluster, providerName string, caBundle *cabundle.Bundle, caBundleEnabled, tracingEnabled bool) (advanced.ClusterAccessReconciler, error) {
+	mcpTokenAccessConfig, err := controller.MCPPermissions(mcpScheme).TokenConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine permissions for mcp cluster: %w", err)
+	}
+	mcpClusterRequest := advanced.ExistingClusterRequest(mcpClusterID, "mcp", mcpClusterReference).
 		WithNamespaceGenerator(advanced.DefaultNamespaceGeneratorForMCP).
 		WithTokenAccess(mcpTokenAccessConfig).
 		WithScheme(mcpScheme).
 		Build()
 
 	// opencontrolplane-gen:if WORKLOADCLUSTER=true
-	// TODO: define minimum set of permission the service provider requires on the workload cluster
-	workloadTokenAccessConfig := &clustersv1alpha1.TokenConfig{
-		Permissions: []clustersv1alpha1.PermissionsRequest{
-			{
-				Rules: []rbacv1.PolicyRule{
-					{
-						APIGroups: []string{"*"},
-						Resources: []string{"*"},
-						Verbs:     []string{"*"},
-					},
-				},
-			},
-		},
-		RoleRefs: []common.RoleRef{
-			{
-				Name: "cluster-admin",
-				Kind: "ClusterRole",
-			},
-		},
+	// the domain service controller gets an access request of its own instead of the credentials of the service provider
+	serviceControllerTokenAccessConfig, err := controller.ServiceControllerMCPPermissions(mcpScheme).TokenConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine permissions of the domain service controller for mcp cluster: %w", err)
 	}
-	workloadClusterRequest := advanced.NewClusterRequest("workload", "wl", advanced.StaticClusterRequestSpecGenerator(&clustersv1alpha1.ClusterRequestSpec{
+	serviceControllerMCPClusterRequest := advanced.ExistingClusterRequest(serviceControllerMCPClusterID, "mcpsc", mcpClusterReference).
+		WithNamespaceGenerator(advanced.DefaultNamespaceGeneratorForMCP).
+		WithTokenAccess(serviceControllerTokenAccessConfig).
+		WithScheme(mcpScheme).
+		Build()
+	workloadClusterRequest := advanced.NewClusterRequest(workloadClusterID, "wl", advanced.StaticClusterRequestSpecGenerator(&clustersv1alpha1.ClusterRequestSpec{
 		Purpose: clustersv1alpha1.PURPOSE_WORKLOAD,
 	})).
 		WithNamespaceGenerator(advanced.DefaultNamespaceGeneratorForMCP).
-		WithTokenAccess(workloadTokenAccessConfig).
+		// the permissions are restricted to the namespace of the service object in the workload cluster
+		WithTokenAccessGenerator(func(req reconcile.Request, _ ...any) (*clustersv1alpha1.TokenConfig, error) {
+			return controller.WorkloadPermissions(workloadScheme, req.NamespacedName).TokenConfig()
+		}).
 		WithScheme(workloadScheme).
 		Build()
 	// opencontrolplane-gen:fi
@@ -417,7 +709,7 @@ func main() {
 		// opencontrolplane-gen:fi
 	}
 
-	clusterAccessReconciler.
+	clusterAccessReconciler = clusterAccessReconciler.
 		WithManagedLabels(func(controllerName string, req reconcile.Request, reg advanced.ClusterRegistration) (string, string, map[string]string) {
 			_, managedPurpose, _ := advanced.DefaultManagedLabelGenerator(controllerName, req, reg)
 			return controllerName, managedPurpose, map[string]string{
@@ -428,49 +720,28 @@ func main() {
 		Register(mcpClusterRequest).
 		// opencontrolplane-gen:if WORKLOADCLUSTER=true
 		Register(workloadClusterRequest).
+		Register(serviceControllerMCPClusterRequest).
 		// opencontrolplane-gen:fi
 		WithRetryInterval(10 * time.Second)
-
-	// opencontrolplane-gen:replace foo=KIND_LOWER Foo=KIND
-	spr := serviceprovider.NewAPIReconcilerBuilder[*foosv1alpha1.Foo, *foosv1alpha1.ProviderConfig]().
-		// opencontrolplane-gen:replace foo=KIND_LOWER Foo=KIND
-		EmptyObjectProvider(func() *foosv1alpha1.Foo { return &foosv1alpha1.Foo{} }).
-		// opencontrolplane-gen:replace foo=KIND_LOWER Foo=KIND
-		EmptyConfigProvider(func() *foosv1alpha1.ProviderConfig { return &foosv1alpha1.ProviderConfig{} }).
-		PlatformCluster(platformCluster).
-		OnboardingCluster(onboardingCluster).
-		// opencontrolplane-gen:if SECRETWATCHER=true
-		SecretNamespace(podNamespace).
-		// opencontrolplane-gen:fi
-		// opencontrolplane-gen:replace Foo=KIND
-		Reconciler(&controller.FooReconciler{
-			OnboardingCluster: onboardingCluster,
-			PlatformCluster:   platformCluster,
-			PodNamespace:      podNamespace,
-		}).
-		AdvancedClusterAccessReconciler(clusterAccessReconciler).
-		MustBuild()
-	if err := spr.SetupWithManager(mgr, providerName); err != nil {
-		// opencontrolplane-gen:replace foo=PROVIDER_NAME
-		setupLog.Error(err, "unable to create controller", "controller", "foo")
-		os.Exit(1)
+	if caBundleEnabled {
+		clusterAccessReconciler = &cabundle.ClusterAccessReconciler{ClusterAccessReconciler: clusterAccessReconciler, Bundle: caBundle}
 	}
-	// +kubebuilder:scaffold:builder
-
-	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up health check")
-		os.Exit(1)
-	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up ready check")
-		os.Exit(1)
+	if tracingEnabled {
+		return &tracing.ClusterAccessReconciler{ClusterAccessReconciler: clusterAccessReconciler}, nil
 	}
+	return clusterAccessReconciler, nil
+}
 
-	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
-		setupLog.Error(err, "problem running manager")
-		os.Exit(1)
+// mcpClusterReference returns the reference to the MCP cluster of the service object of the request.
+func mcpClusterReference(req reconcile.Request, _ ...any) (*common.ObjectReference, error) {
+	namespace, err := utils.StableMCPNamespace(req.Name, req.Namespace)
+	if err != nil {
+		return nil, err
 	}
+	return &common.ObjectReference{
+		Name:      req.Name,
+		Namespace: namespace,
+	}, nil
 }
 
 // initializePlatformCluster initializes the platform cluster with the necessary REST config and client.
@@ -486,18 +757,86 @@ func initializePlatformCluster() (*clusters.Cluster, error) {
 	return platformCluster, nil
 }
 
-func requestOnboardingClusterAccess(ctx context.Context, mgr clusteraccess.Manager, platformCluster *clusters.Cluster, permissions []clustersv1alpha1.PermissionsRequest, cmdSuffix string) (*clusters.Cluster, error) {
+func requestOnboardingClusterAccess(ctx context.Context, mgr clusteraccess.Manager, platformCluster *clusters.Cluster, caBundle *cabundle.Bundle, permissions []clustersv1alpha1.PermissionsRequest, cmdSuffix string) (*clusters.Cluster, error) {
+	start := time.Now()
 	cluster, err := mgr.CreateAndWaitForCluster(ctx, "onboarding-"+cmdSuffix,
 		clustersv1alpha1.PURPOSE_ONBOARDING, onboardingScheme, permissions)
+	metrics.ObserveAccessRequestWait("onboarding-"+cmdSuffix, time.Since(start), err)
 	if err != nil {
 		return cluster, err
 	}
+	if cluster, err = withCABundle(cluster, onboardingScheme, caBundle.Get()); err != nil {
+		return cluster, err
+	}
 	if debugEnabled() {
 		return patchOnboardingClient(ctx, platformCluster, cluster, "onboarding-"+cmdSuffix)
 	}
 	return cluster, nil
 }
 
+// eventDeduplicationWindow is the time identical events on a service object are suppressed.
+const eventDeduplicationWindow = 10 * time.Minute
+
+// onboardingTokenMinValidity is the minimum remaining validity of the onboarding cluster token for the service provider to be live.
+const onboardingTokenMinValidity = 5 * time.Minute
+
+// onboardingAccessBackoff defines the retries of the run command to obtain access to the onboarding cluster.
+// Once the cap is reached, access is requested every five minutes until it is granted.
+var onboardingAccessBackoff = wait.Backoff{
+	Duration: 10 * time.Second,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    math.MaxInt32,
+	Cap:      5 * time.Minute,
+}
+
+// waitForOnboardingClusterAccess calls request until access to the onboarding cluster is granted and retries failed attempts
+// with onboardingAccessBackoff. Meanwhile, the health probes are served on probeAddr and the readiness probe reports
+// that the service provider is waiting for onboarding access. It only fails if the context is canceled or the probes cannot be served.
+func waitForOnboardingClusterAccess(ctx context.Context, probeAddr string, request func(ctx context.Context) (*clusters.Cluster, error)) (*clusters.Cluster, error) {
+	probes := &health.WaitingProbes{Addr: probeAddr, Reason: "waiting for onboarding access"}
+	if err := probes.Start(); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := probes.Stop(context.Background()); err != nil {
+			setupLog.Error(err, "unable to stop health probes")
+		}
+	}()
+
+	backoff := onboardingAccessBackoff
+	for {
+		cluster, err := request(ctx)
+		if err == nil {
+			return cluster, nil
+		}
+		probes.SetError(err)
+		delay := backoff.Step()
+		setupLog.Error(err, "Failed to obtain onboarding cluster access, retrying", "after", delay.String())
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// withCABundle recreates the cluster with a REST config that trusts the CA bundle in addition.
+func withCABundle(cluster *clusters.Cluster, scheme *runtime.Scheme, bundle []byte) (*clusters.Cluster, error) {
+	if len(bundle) == 0 {
+		return cluster, nil
+	}
+	cfg, err := cabundle.RESTConfig(cluster.RESTConfig(), bundle)
+	if err != nil {
+		return cluster, err
+	}
+	c := clusters.New(cluster.ID()).WithRESTConfig(cfg)
+	if err := c.InitializeClient(scheme); err != nil {
+		return cluster, err
+	}
+	return c, nil
+}
+
 func patchOnboardingClient(ctx context.Context, platformCluster *clusters.Cluster, onboardingCluster *clusters.Cluster, cmdSuffix string) (*clusters.Cluster, error) {
 	onboardingAr := &clustersv1alpha1.AccessRequest{
 		ObjectMeta: metav1.ObjectMeta{
@@ -512,6 +851,68 @@ func patchOnboardingClient(ctx context.Context, platformCluster *clusters.Cluste
 	return localaccess.MustPatchClusterClient(ctx, onboardingAr, onboardingCluster), nil
 }
 
+// registerSelfSignedWebhooks registers the webhooks with the CA bundle of the self-signed certificate.
+// The ProviderConfig webhooks are registered on the platform cluster and called via the webhook service,
+// the service object webhooks are registered on the onboarding cluster and called via the webhook URL.
+// The CRDs of both clusters are configured to convert between API versions via the conversion webhook.
+func registerSelfSignedWebhooks(ctx context.Context, platformCluster, onboardingCluster *clusters.Cluster, namespace, serviceName, webhookURL string, caBundle []byte) error {
+	// opencontrolplane-gen:replace foo=KIND_LOWER
+	name := foosv1alpha1.GroupVersion.Group
+	platformConfig := selfsigned.ServiceClientConfig(namespace, serviceName, webhookServicePort, caBundle)
+	if err := selfsigned.EnsureValidatingWebhookConfiguration(ctx, platformCluster.Client(), name, platformConfig,
+		webhookv1alpha1.ProviderConfigValidatingWebhook()); err != nil {
+		return fmt.Errorf("platform cluster: %w", err)
+	}
+	if err := selfsigned.EnsureMutatingWebhookConfiguration(ctx, platformCluster.Client(), name, platformConfig,
+		webhookv1alpha1.ProviderConfigMutatingWebhook()); err != nil {
+		return fmt.Errorf("platform cluster: %w", err)
+	}
+	providerConfigCRD, err := manifestCRD(webhookv1alpha1.ProviderConfigCRDName())
+	if err != nil {
+		return err
+	}
+	if err := selfsigned.EnsureConversionWebhook(ctx, platformCluster.Client(), providerConfigCRD,
+		webhookv1alpha1.ConversionPath, platformConfig); err != nil {
+		return fmt.Errorf("platform cluster: %w", err)
+	}
+	onboardingConfig := selfsigned.URLClientConfig(webhookURL, caBundle)
+	if err := selfsigned.EnsureValidatingWebhookConfiguration(ctx, onboardingCluster.Client(), name, onboardingConfig,
+		// opencontrolplane-gen:replace Foo=KIND
+		webhookv1alpha1.FooValidatingWebhook()); err != nil {
+		return fmt.Errorf("onboarding cluster: %w", err)
+	}
+	if err := selfsigned.EnsureMutatingWebhookConfiguration(ctx, onboardingCluster.Client(), name, onboardingConfig,
+		// opencontrolplane-gen:replace Foo=KIND
+		webhookv1alpha1.FooMutatingWebhook()); err != nil {
+		return fmt.Errorf("onboarding cluster: %w", err)
+	}
+	// opencontrolplane-gen:replace Foo=KIND foo=KIND_LOWER
+	fooCRD, err := manifestCRD(webhookv1alpha1.FooCRDName())
+	if err != nil {
+		return err
+	}
+	// opencontrolplane-gen:replace foo=KIND_LOWER
+	if err := selfsigned.EnsureConversionWebhook(ctx, onboardingCluster.Client(), fooCRD,
+		webhookv1alpha1.ConversionPath, onboardingConfig); err != nil {
+		return fmt.Errorf("onboarding cluster: %w", err)
+	}
+	return nil
+}
+
+// manifestCRD returns the CRD with the given name from the manifests of the service provider.
+func manifestCRD(name string) (*apiextensionv1.CustomResourceDefinition, error) {
+	crdList, err := crds.CRDs()
+	if err != nil {
+		return nil, err
+	}
+	for _, crd := range crdList {
+		if crd.Name == name {
+			return crd, nil
+		}
+	}
+	return nil, fmt.Errorf("CRD %s is not part of the manifests", name)
+}
+
 func debugEnabled() bool {
 	v := strings.ToLower(os.Getenv(debugEnvVar))
 	return v == "1" || v == "true"
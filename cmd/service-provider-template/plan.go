@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/openmcp-project/controller-utils/pkg/clusters"
+	localaccess "github.com/openmcp-project/opencontrolplane-runtime/pkg/serviceprovider/clusteraccess"
+	clustersv1alpha1 "github.com/openmcp-project/openmcp-operator/api/clusters/v1alpha1"
+	"github.com/openmcp-project/openmcp-operator/lib/clusteraccess"
+	"github.com/openmcp-project/openmcp-operator/lib/clusteraccess/advanced"
+	rbacv1 "k8s.io/api/rbac/v1"
+	meta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	// opencontrolplane-gen:replace foo=KIND_LOWER github.com/openmcp-project/service-provider-template=MODULE
+	foosv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/cabundle"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/controller"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/plan"
+)
+
+// planOptions configure the plan command.
+type planOptions struct {
+	providerName    string
+	podNamespace    string
+	caBundle        *cabundle.Bundle
+	caBundleEnabled bool
+}
+
+// runPlan reconciles every service object against server-side dry-run clients and writes the changes
+// that would be made on its MCP and workload cluster to out. Nothing is mutated on any cluster,
+// apart from the access request for the onboarding cluster of the plan command itself.
+func runPlan(ctx context.Context, accessManager clusteraccess.Manager, platformCluster *clusters.Cluster, opts planOptions, out io.Writer) error {
+	planPermissions := []clustersv1alpha1.PermissionsRequest{
+		{
+			Rules: []rbacv1.PolicyRule{
+				{
+					// opencontrolplane-gen:replace foo=KIND_LOWER
+					APIGroups: []string{foosv1alpha1.GroupVersion.Group},
+					Resources: []string{"*"},
+					// patch is required for the dry-run requests that remove the reconcile operation annotation
+					Verbs: []string{"get", "list", "patch"},
+				},
+			},
+		},
+	}
+	onboardingCluster, err := requestOnboardingClusterAccess(ctx, accessManager, platformCluster, opts.caBundle, planPermissions, "plan")
+	if err != nil {
+		return fmt.Errorf("unable to get access to the onboarding cluster: %w", err)
+	}
+	clusterAccessReconciler, err := newClusterAccessReconciler(platformCluster, opts.providerName, opts.caBundle, opts.caBundleEnabled, false)
+	if err != nil {
+		return err
+	}
+
+	// opencontrolplane-gen:replace foo=KIND_LOWER
+	pc := &foosv1alpha1.ProviderConfig{}
+	if err := platformCluster.Client().Get(ctx, client.ObjectKey{Name: opts.providerName}, pc); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("unable to get ProviderConfig %s: %w", opts.providerName, err)
+	}
+	// opencontrolplane-gen:replace Foo=KIND
+	list := &foosv1alpha1.FooList{}
+	if err := onboardingCluster.Client().List(ctx, list); err != nil {
+		return fmt.Errorf("unable to list service objects: %w", err)
+	}
+
+	// opencontrolplane-gen:replace Foo=KIND
+	reconciler := &controller.FooReconciler{
+		OnboardingCluster: clusters.NewTestClusterFromClient(onboardingCluster.ID(), client.NewDryRunClient(onboardingCluster.Client())),
+		PlatformCluster:   platformCluster,
+		PodNamespace:      opts.podNamespace,
+		FieldManager:      opts.providerName,
+	}
+	var failed int
+	for i := range list.Items {
+		obj := &list.Items[i]
+		// opencontrolplane-gen:replace Foo=KIND
+		fmt.Fprintf(out, "Foo %s/%s\n", obj.Namespace, obj.Name)
+		if err := planObject(ctx, reconciler, clusterAccessReconciler, obj, pc, out); err != nil {
+			fmt.Fprintf(out, "  failed: %v\n", err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("unable to plan %d of %d service objects", failed, len(list.Items))
+	}
+	return nil
+}
+
+// planObject reconciles a copy of the service object with clients that record the changes instead of applying them.
+// opencontrolplane-gen:replace Foo=KIND
+func planObject(ctx context.Context, reconciler *controller.FooReconciler, clusterAccessReconciler advanced.ClusterAccessReconciler, obj *foosv1alpha1.Foo, pc *foosv1alpha1.ProviderConfig, out io.Writer) error {
+	if obj.DeletionTimestamp != nil {
+		fmt.Fprintln(out, "  skipped, the object is being deleted")
+		return nil
+	}
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)}
+	mcpCluster, err := clusterAccessReconciler.Access(ctx, req, mcpClusterID)
+	if err != nil {
+		return fmt.Errorf("no access to the MCP cluster: %w", err)
+	}
+	// the reconciler only uses the clients of the clusters
+	mcpRecorder := plan.NewRecorder(mcpCluster.Client())
+	clusterContext := localaccess.ClusterContext{
+		MCPCluster: clusters.NewTestClusterFromClient(mcpCluster.ID(), mcpRecorder),
+	}
+	// opencontrolplane-gen:if WORKLOADCLUSTER=true
+	workloadCluster, err := clusterAccessReconciler.Access(ctx, req, workloadClusterID)
+	if err != nil {
+		return fmt.Errorf("no access to the workload cluster: %w", err)
+	}
+	workloadRecorder := plan.NewRecorder(workloadCluster.Client())
+	clusterContext.WorkloadCluster = clusters.NewTestClusterFromClient(workloadCluster.ID(), workloadRecorder)
+	// opencontrolplane-gen:fi
+
+	planned := obj.DeepCopy()
+	if _, err := reconciler.CreateOrUpdate(ctx, planned, pc, clusterContext); err != nil {
+		return err
+	}
+	if con := meta.FindStatusCondition(planned.Status.Conditions, foosv1alpha1.ConditionTypeDegraded); con != nil && con.Status == metav1.ConditionTrue {
+		fmt.Fprintf(out, "  degraded: %s\n", con.Message)
+	}
+	printChanges(out, "MCP cluster", mcpRecorder.Changes())
+	// opencontrolplane-gen:if WORKLOADCLUSTER=true
+	printChanges(out, "workload cluster", workloadRecorder.Changes())
+	// opencontrolplane-gen:fi
+	return nil
+}
+
+// printChanges writes the changes of a cluster to out.
+func printChanges(out io.Writer, cluster string, changes []plan.Change) {
+	if len(changes) == 0 {
+		fmt.Fprintf(out, "  %s: no changes\n", cluster)
+		return
+	}
+	fmt.Fprintf(out, "  %s: %d change(s)\n", cluster, len(changes))
+	for _, c := range changes {
+		fmt.Fprintln(out, c.String())
+	}
+}
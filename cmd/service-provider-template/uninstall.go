@@ -0,0 +1,245 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/openmcp-project/controller-utils/pkg/clusters"
+	clustersv1alpha1 "github.com/openmcp-project/openmcp-operator/api/clusters/v1alpha1"
+	providerv1alpha1 "github.com/openmcp-project/openmcp-operator/api/provider/v1alpha1"
+	"github.com/openmcp-project/openmcp-operator/lib/clusteraccess"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/api/crds"
+	// opencontrolplane-gen:replace foo=KIND_LOWER github.com/openmcp-project/service-provider-template=MODULE
+	foosv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/cabundle"
+)
+
+// onboardingAccessCommands are the commands that request access to the onboarding cluster.
+// The access request of the uninstall command itself is deleted last.
+var onboardingAccessCommands = []string{"init", "run", "plan", "uninstall"}
+
+// uninstallPollInterval is the interval the uninstall command checks whether service objects and CRDs are gone.
+var uninstallPollInterval = 5 * time.Second
+
+// uninstallOptions configure the uninstall command.
+type uninstallOptions struct {
+	providerName string
+	podNamespace string
+	caBundle     *cabundle.Bundle
+	// drain deletes the remaining service objects and waits until they are gone instead of refusing to uninstall.
+	drain        bool
+	drainTimeout time.Duration
+}
+
+// runUninstall reverses init: it removes the CRDs of the service provider from the platform and onboarding cluster,
+// deregisters the service object kind from the ServiceProvider resource and deletes the access requests
+// for the onboarding cluster. It refuses to proceed while service objects exist, unless they are drained.
+// The access requests are only deleted once the CRDs are gone, so that the run command keeps its access
+// until it has removed the finalizers of all service objects.
+func runUninstall(ctx context.Context, accessManager clusteraccess.Manager, platformCluster *clusters.Cluster, opts uninstallOptions) error {
+	uninstallPermissions := []clustersv1alpha1.PermissionsRequest{
+		{
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{apiextensionv1.GroupName},
+					Resources: []string{"customresourcedefinitions"},
+					Verbs:     []string{"get", "delete"},
+				},
+				{
+					// opencontrolplane-gen:replace foo=KIND_LOWER
+					APIGroups: []string{foosv1alpha1.GroupVersion.Group},
+					Resources: []string{"*"},
+					Verbs:     []string{"get", "list", "delete"},
+				},
+			},
+		},
+	}
+	onboardingCluster, err := requestOnboardingClusterAccess(ctx, accessManager, platformCluster, opts.caBundle, uninstallPermissions, "uninstall")
+	if err != nil {
+		return fmt.Errorf("unable to get access to the onboarding cluster: %w", err)
+	}
+
+	if opts.drain {
+		if err := drainServiceObjects(ctx, onboardingCluster.Client(), opts.drainTimeout); err != nil {
+			return err
+		}
+	} else if err := ensureNoServiceObjects(ctx, onboardingCluster.Client()); err != nil {
+		return err
+	}
+	if err := deleteCRDs(ctx, platformCluster, onboardingCluster); err != nil {
+		return err
+	}
+	// the run command may still be finalizing service objects that were created meanwhile, which needs its access request
+	if err := waitForCRDsDeleted(ctx, platformCluster, onboardingCluster, opts.drainTimeout); err != nil {
+		return err
+	}
+	if err := deregisterGVKsAtServiceProvider(ctx, platformCluster.Client(), opts.providerName); err != nil {
+		return fmt.Errorf("unable to deregister GVKs at ServiceProvider %s: %w", opts.providerName, err)
+	}
+	return deleteOnboardingAccessRequests(ctx, platformCluster.Client(), opts.podNamespace)
+}
+
+// ensureNoServiceObjects returns an error listing the service objects that still exist in the onboarding cluster.
+func ensureNoServiceObjects(ctx context.Context, c client.Client) error {
+	// opencontrolplane-gen:replace Foo=KIND
+	list := &foosv1alpha1.FooList{}
+	if err := c.List(ctx, list); err != nil {
+		return fmt.Errorf("unable to list service objects: %w", err)
+	}
+	if len(list.Items) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(list.Items))
+	for _, obj := range list.Items {
+		names = append(names, obj.Namespace+"/"+obj.Name)
+	}
+	return fmt.Errorf("%d service objects still exist, delete them or use --uninstall-drain: %v", len(names), names)
+}
+
+// drainServiceObjects deletes all service objects in the onboarding cluster and waits until their finalizers
+// are removed by the running service provider, which cleans up the managed resources according to their deletion policy.
+func drainServiceObjects(ctx context.Context, c client.Client, timeout time.Duration) error {
+	// opencontrolplane-gen:replace Foo=KIND
+	list := &foosv1alpha1.FooList{}
+	if err := c.List(ctx, list); err != nil {
+		return fmt.Errorf("unable to list service objects: %w", err)
+	}
+	for i := range list.Items {
+		obj := &list.Items[i]
+		if obj.DeletionTimestamp != nil {
+			continue
+		}
+		setupLog.Info("Deleting service object", "namespace", obj.Namespace, "name", obj.Name)
+		if err := c.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("unable to delete service object %s/%s: %w", obj.Namespace, obj.Name, err)
+		}
+	}
+	err := wait.PollUntilContextTimeout(ctx, uninstallPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		if err := c.List(ctx, list); err != nil {
+			return false, err
+		}
+		if len(list.Items) > 0 {
+			setupLog.Info("Waiting for service objects to be deleted", "remaining", len(list.Items))
+		}
+		return len(list.Items) == 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("service objects have not been deleted within %s: %w", timeout, err)
+	}
+	return nil
+}
+
+// deleteCRDs deletes the CRDs of the service provider from the platform or onboarding cluster according to their cluster label.
+func deleteCRDs(ctx context.Context, platformCluster, onboardingCluster *clusters.Cluster) error {
+	crdList, err := crds.CRDs()
+	if err != nil {
+		return err
+	}
+	for _, crd := range crdList {
+		cluster, err := crdCluster(crd, platformCluster, onboardingCluster)
+		if err != nil {
+			return err
+		}
+		setupLog.Info("Deleting CRD", "name", crd.Name, "cluster", cluster.ID())
+		if err := cluster.Client().Delete(ctx, crd); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("unable to delete CRD %s from %s cluster: %w", crd.Name, cluster.ID(), err)
+		}
+	}
+	return nil
+}
+
+// waitForCRDsDeleted waits until the CRDs of the service provider are gone from the platform and onboarding cluster.
+// The API server deletes the remaining objects of a CRD before the CRD itself, so once it is gone,
+// the running service provider has removed the finalizers of all service objects.
+func waitForCRDsDeleted(ctx context.Context, platformCluster, onboardingCluster *clusters.Cluster, timeout time.Duration) error {
+	crdList, err := crds.CRDs()
+	if err != nil {
+		return err
+	}
+	err = wait.PollUntilContextTimeout(ctx, uninstallPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		for _, crd := range crdList {
+			cluster, err := crdCluster(crd, platformCluster, onboardingCluster)
+			if err != nil {
+				return false, err
+			}
+			err = cluster.Client().Get(ctx, client.ObjectKeyFromObject(crd), &apiextensionv1.CustomResourceDefinition{})
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			if err == nil {
+				setupLog.Info("Waiting for CRD to be deleted", "name", crd.Name, "cluster", cluster.ID())
+			}
+			return false, err
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("CRDs have not been deleted within %s, the access requests for the onboarding cluster are kept: %w", timeout, err)
+	}
+	return nil
+}
+
+// deregisterGVKsAtServiceProvider removes the GVKs of the service object kind from the status of the ServiceProvider resource.
+// It is the counterpart of utils.RegisterGVKsAtServiceProvider.
+func deregisterGVKsAtServiceProvider(ctx context.Context, c client.Client, providerName string) error {
+	sp := &providerv1alpha1.ServiceProvider{}
+	if err := c.Get(ctx, client.ObjectKey{Name: providerName}, sp); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	old := sp.DeepCopy()
+	sp.Status.Resources = slices.DeleteFunc(sp.Status.Resources, func(gvk metav1.GroupVersionKind) bool {
+		// opencontrolplane-gen:replace foo=KIND_LOWER Foo=KIND
+		return gvk.Group == foosv1alpha1.GroupVersion.Group && gvk.Kind == "Foo"
+	})
+	if len(sp.Status.Resources) == len(old.Status.Resources) {
+		return nil
+	}
+	setupLog.Info("Deregistering GVKs at ServiceProvider", "name", providerName)
+	return c.Status().Patch(ctx, sp, client.MergeFrom(old))
+}
+
+// deleteOnboardingAccessRequests deletes the access requests for the onboarding cluster of all commands.
+func deleteOnboardingAccessRequests(ctx context.Context, c client.Client, namespace string) error {
+	for _, cmd := range onboardingAccessCommands {
+		ar := &clustersv1alpha1.AccessRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				// opencontrolplane-gen:replace foo=KIND_LOWER
+				Name:      clusteraccess.StableRequestNameFromLocalName(foosv1alpha1.GroupVersion.Group, "onboarding-"+cmd),
+				Namespace: namespace,
+			},
+		}
+		setupLog.Info("Deleting AccessRequest", "name", ar.Name, "namespace", ar.Namespace)
+		if err := c.Delete(ctx, ar); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("unable to delete AccessRequest %s: %w", ar.Name, err)
+		}
+	}
+	return nil
+}
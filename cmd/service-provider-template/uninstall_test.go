@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openmcp-project/controller-utils/pkg/clusters"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/api/crds"
+	// opencontrolplane-gen:replace foo=KIND_LOWER github.com/openmcp-project/service-provider-template=MODULE
+	foosv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// testServiceObject returns a service object with the given finalizers.
+// opencontrolplane-gen:replace foo=KIND_LOWER Foo=KIND
+func testServiceObject(name string, finalizers ...string) *foosv1alpha1.Foo {
+	// opencontrolplane-gen:replace foo=KIND_LOWER Foo=KIND
+	return &foosv1alpha1.Foo{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "project", Finalizers: finalizers}}
+}
+
+// shortUninstallPollInterval lets the uninstall command poll without delay during the test.
+func shortUninstallPollInterval(t *testing.T) {
+	interval := uninstallPollInterval
+	uninstallPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { uninstallPollInterval = interval })
+}
+
+func TestEnsureNoServiceObjects(t *testing.T) {
+	tests := []struct {
+		name      string
+		objects   []client.Object
+		wantNames []string
+	}{
+		{name: "no service objects"},
+		{
+			name:      "remaining service objects are listed",
+			objects:   []client.Object{testServiceObject("a"), testServiceObject("b")},
+			wantNames: []string{"project/a", "project/b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(onboardingScheme).WithObjects(tt.objects...).Build()
+			err := ensureNoServiceObjects(context.Background(), c)
+			if (err != nil) != (len(tt.wantNames) > 0) {
+				t.Fatalf("ensureNoServiceObjects() error = %v, want names %v", err, tt.wantNames)
+			}
+			for _, name := range tt.wantNames {
+				if !strings.Contains(err.Error(), name) {
+					t.Errorf("ensureNoServiceObjects() error = %v, want it to name %s", err, name)
+				}
+			}
+		})
+	}
+}
+
+func TestDrainServiceObjects(t *testing.T) {
+	shortUninstallPollInterval(t)
+	tests := []struct {
+		name    string
+		objects []client.Object
+		wantErr bool
+	}{
+		{name: "no service objects"},
+		{
+			name:    "service objects are deleted",
+			objects: []client.Object{testServiceObject("a"), testServiceObject("b")},
+		},
+		{
+			// the finalizer is never removed, as no service provider is running
+			name:    "timeout while service objects are finalized",
+			objects: []client.Object{testServiceObject("a", "test/finalizer")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(onboardingScheme).WithObjects(tt.objects...).Build()
+			err := drainServiceObjects(context.Background(), c, 100*time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("drainServiceObjects() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			// opencontrolplane-gen:replace foo=KIND_LOWER Foo=KIND
+			list := &foosv1alpha1.FooList{}
+			if err := c.List(context.Background(), list); err != nil {
+				t.Fatal(err)
+			}
+			for _, obj := range list.Items {
+				if obj.DeletionTimestamp == nil {
+					t.Errorf("service object %s has not been deleted", obj.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestWaitForCRDsDeleted(t *testing.T) {
+	shortUninstallPollInterval(t)
+	crdList, err := crds.CRDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		objects []client.Object
+		wantErr bool
+	}{
+		{name: "CRDs are gone"},
+		{
+			// the access requests must be kept while the API server waits for the service objects to be finalized
+			name:    "CRD is still terminating",
+			objects: []client.Object{crdList[0]},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// both clusters share the client, as it does not matter for the check on which cluster the CRD remains
+			c := fake.NewClientBuilder().WithScheme(platformScheme).WithObjects(tt.objects...).Build()
+			platformCluster := clusters.NewTestClusterFromClient("platform", c)
+			onboardingCluster := clusters.NewTestClusterFromClient("onboarding", c)
+			err := waitForCRDsDeleted(context.Background(), platformCluster, onboardingCluster, 100*time.Millisecond)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("waitForCRDsDeleted() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cabundle adds a custom CA bundle to the REST configs of the cluster clients,
+// e.g. for API servers with certificates issued by a private PKI.
+package cabundle
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultKey is the key of the CA bundle in the ConfigMap or Secret if none is specified.
+const DefaultKey = "ca.crt"
+
+// Source references a CA bundle in a ConfigMap or a Secret.
+type Source struct {
+	// Namespace of the ConfigMap or Secret.
+	Namespace string
+	// ConfigMapName is the name of the ConfigMap that contains the CA bundle.
+	ConfigMapName string
+	// SecretName is the name of the Secret that contains the CA bundle.
+	SecretName string
+	// Key is the key of the CA bundle in the ConfigMap or Secret.
+	Key string
+}
+
+// Enabled returns true if a ConfigMap or Secret is referenced.
+func (s Source) Enabled() bool {
+	return s.ConfigMapName != "" || s.SecretName != ""
+}
+
+// Validate checks that at most one of ConfigMap and Secret is referenced.
+func (s Source) Validate() error {
+	if s.ConfigMapName != "" && s.SecretName != "" {
+		return fmt.Errorf("CA bundle can either be read from a ConfigMap or from a Secret, not both")
+	}
+	return nil
+}
+
+// Load reads the CA bundle and checks that it contains at least one PEM encoded certificate.
+func (s Source) Load(ctx context.Context, c client.Client) ([]byte, error) {
+	key := s.Key
+	if key == "" {
+		key = DefaultKey
+	}
+	var data []byte
+	if s.ConfigMapName != "" {
+		cm := &corev1.ConfigMap{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: s.Namespace, Name: s.ConfigMapName}, cm); err != nil {
+			return nil, fmt.Errorf("unable to get CA bundle ConfigMap %s/%s: %w", s.Namespace, s.ConfigMapName, err)
+		}
+		data = []byte(cm.Data[key])
+	} else {
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: s.Namespace, Name: s.SecretName}, secret); err != nil {
+			return nil, fmt.Errorf("unable to get CA bundle Secret %s/%s: %w", s.Namespace, s.SecretName, err)
+		}
+		data = secret.Data[key]
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("key %q of the CA bundle does not contain any PEM encoded certificate", key)
+	}
+	return data, nil
+}
+
+// Bundle holds the currently loaded CA bundle.
+type Bundle struct {
+	mu   sync.RWMutex
+	data []byte
+}
+
+// Get returns the current CA bundle, nil if none is loaded.
+func (b *Bundle) Get() []byte {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.data
+}
+
+// Set replaces the current CA bundle.
+func (b *Bundle) Set(data []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.data = data
+}
+
+// RESTConfig returns a copy of cfg that trusts the CAs of the bundle in addition to the CAs already configured.
+func RESTConfig(cfg *rest.Config, bundle []byte) (*rest.Config, error) {
+	if len(bundle) == 0 || cfg.Insecure {
+		return cfg, nil
+	}
+	cfg = rest.CopyConfig(cfg)
+	ca := cfg.CAData
+	if len(ca) == 0 && cfg.CAFile != "" {
+		data, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA file %s: %w", cfg.CAFile, err)
+		}
+		ca = data
+	}
+	merged := make([]byte, 0, len(ca)+len(bundle)+1)
+	merged = append(merged, ca...)
+	if len(merged) > 0 && merged[len(merged)-1] != '\n' {
+		merged = append(merged, '\n')
+	}
+	cfg.CAData = append(merged, bundle...)
+	cfg.CAFile = ""
+	return cfg, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cabundle
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// testCertificate returns a PEM encoded self-signed CA certificate.
+func testCertificate(t *testing.T, cn string) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func testConfigMap(data string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "ca-bundle", Namespace: "default"},
+		Data:       map[string]string{DefaultKey: data},
+	}
+}
+
+func TestSourceLoad(t *testing.T) {
+	ca := testCertificate(t, "custom-ca")
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ca-bundle", Namespace: "default"},
+		Data:       map[string][]byte{"bundle.pem": ca},
+	}
+	c := fake.NewClientBuilder().WithObjects(testConfigMap(string(ca)), secret).Build()
+	tests := []struct {
+		name    string
+		source  Source
+		wantErr bool
+	}{
+		{name: "ConfigMap with the default key", source: Source{Namespace: "default", ConfigMapName: "ca-bundle"}},
+		{name: "Secret with a custom key", source: Source{Namespace: "default", SecretName: "ca-bundle", Key: "bundle.pem"}},
+		{name: "missing key", source: Source{Namespace: "default", SecretName: "ca-bundle"}, wantErr: true},
+		{name: "missing ConfigMap", source: Source{Namespace: "default", ConfigMapName: "other"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.source.Load(context.Background(), c)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !bytes.Equal(data, ca) {
+				t.Errorf("Load() = %q, want %q", data, ca)
+			}
+		})
+	}
+}
+
+func TestRESTConfig(t *testing.T) {
+	existing := testCertificate(t, "cluster-ca")
+	bundle := testCertificate(t, "custom-ca")
+	cfg, err := RESTConfig(&rest.Config{Host: "https://api.example.com", TLSClientConfig: rest.TLSClientConfig{CAData: existing}}, bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(cfg.CAData, existing) || !bytes.HasSuffix(cfg.CAData, bundle) {
+		t.Errorf("CAData = %q, want the cluster CA followed by the bundle", cfg.CAData)
+	}
+
+	insecure := &rest.Config{TLSClientConfig: rest.TLSClientConfig{Insecure: true}}
+	if cfg, err := RESTConfig(insecure, bundle); err != nil || len(cfg.CAData) != 0 {
+		t.Errorf("RESTConfig() of an insecure config = %q, %v, want it unchanged", cfg.CAData, err)
+	}
+}
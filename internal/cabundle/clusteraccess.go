@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cabundle
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openmcp-project/controller-utils/pkg/clusters"
+	"github.com/openmcp-project/openmcp-operator/lib/clusteraccess/advanced"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ClusterAccessReconciler adds the CA bundle to the clusters returned by the wrapped reconciler.
+// It must wrap the fully configured reconciler, because the builder methods return the wrapped reconciler.
+type ClusterAccessReconciler struct {
+	advanced.ClusterAccessReconciler
+	// Bundle is the CA bundle the clients trust in addition. The bundle that is current at the time of the access is used,
+	// so that a changed bundle is picked up without a restart.
+	Bundle *Bundle
+}
+
+var _ advanced.ClusterAccessReconciler = &ClusterAccessReconciler{}
+
+// Access returns the cluster of the wrapped reconciler with a REST config and client that trust the CA bundle.
+// The REST config is kept, so that the credentials and the merged CAs can be passed on, e.g. as kubeconfig.
+// Clusters without a REST config are returned unchanged.
+func (r *ClusterAccessReconciler) Access(ctx context.Context, request reconcile.Request, id string, additionalData ...any) (*clusters.Cluster, error) {
+	cluster, err := r.ClusterAccessReconciler.Access(ctx, request, id, additionalData...)
+	if err != nil || cluster == nil || !cluster.HasRESTConfig() {
+		return cluster, err
+	}
+	bundle := r.Bundle.Get()
+	if len(bundle) == 0 {
+		return cluster, nil
+	}
+	cfg, err := RESTConfig(cluster.RESTConfig(), bundle)
+	if err != nil {
+		return nil, fmt.Errorf("unable to add CA bundle to cluster '%s': %w", id, err)
+	}
+	// the reconciler creates a new cluster on every access, so it is safe to replace its client
+	if err := cluster.WithRESTConfig(cfg).InitializeClient(cluster.Scheme()); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cabundle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultWatchInterval is the interval in which the CA bundle is checked for changes if none is specified.
+const DefaultWatchInterval = time.Minute
+
+// ErrBundleChanged is returned by the Watcher if the CA bundle changed and long-lived clients have to be recreated.
+var ErrBundleChanged = errors.New("CA bundle changed")
+
+// Watcher periodically reloads the CA bundle.
+// Clients that are created per access, e.g. for the MCP and workload clusters, use the new bundle right away.
+// Clients that have been created at startup cannot be updated in place, so the watcher returns ErrBundleChanged
+// which stops the manager and lets the service provider restart with the new bundle.
+type Watcher struct {
+	// Source references the CA bundle.
+	Source Source
+	// Client reads the CA bundle.
+	Client client.Client
+	// Bundle is updated with the reloaded CA bundle.
+	Bundle *Bundle
+	// Interval is the interval in which the CA bundle is checked for changes.
+	Interval time.Duration
+}
+
+// NeedLeaderElection returns false, every replica has to reload its clients.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start checks the CA bundle for changes until the context is cancelled or the bundle changed.
+func (w *Watcher) Start(ctx context.Context) error {
+	l := logf.FromContext(ctx).WithName("cabundle")
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		data, err := w.Source.Load(ctx, w.Client)
+		if err != nil {
+			// keep the current bundle, the source may be updated in several steps
+			l.Error(err, "reloading CA bundle failed")
+			continue
+		}
+		if bytes.Equal(data, w.Bundle.Get()) {
+			continue
+		}
+		w.Bundle.Set(data)
+		l.Info("CA bundle changed, restarting to recreate cluster clients")
+		return ErrBundleChanged
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cabundle
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWatcherStart(t *testing.T) {
+	current := testCertificate(t, "current-ca")
+	rotated := testCertificate(t, "rotated-ca")
+	tests := []struct {
+		name       string
+		configMap  string
+		wantErr    error
+		wantBundle []byte
+	}{
+		{name: "unchanged bundle", configMap: string(current), wantBundle: current},
+		{name: "invalid bundle keeps the current bundle", configMap: "not a certificate", wantBundle: current},
+		{name: "changed bundle", configMap: string(rotated), wantErr: ErrBundleChanged, wantBundle: rotated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundle := &Bundle{}
+			bundle.Set(current)
+			w := &Watcher{
+				Source:   Source{Namespace: "default", ConfigMapName: "ca-bundle"},
+				Client:   fake.NewClientBuilder().WithObjects(testConfigMap(tt.configMap)).Build(),
+				Bundle:   bundle,
+				Interval: time.Millisecond,
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			if err := w.Start(ctx); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Start() error = %v, want %v", err, tt.wantErr)
+			}
+			if !bytes.Equal(bundle.Get(), tt.wantBundle) {
+				t.Errorf("bundle = %q, want %q", bundle.Get(), tt.wantBundle)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/metrics"
+)
+
+// opencontrolplane-gen:replace Foo=KIND
+// fieldManager returns the field manager used to apply managed objects.
+// opencontrolplane-gen:replace Foo=KIND
+func (r *FooReconciler) fieldManager() string {
+	if r.FieldManager != "" {
+		return r.FieldManager
+	}
+	return apiv1alpha1.GroupVersion.Group
+}
+
+// applyObject applies the desired state of obj with server-side apply and updates obj with the result.
+// Fields owned by other field managers, e.g. another controller or kubectl edit, are only taken over if forceConflicts is set,
+// otherwise a conflict error is returned.
+func applyObject(ctx context.Context, c client.Client, obj client.Object, fieldManager string, forceConflicts bool) error {
+	gvk, err := c.GroupVersionKindFor(obj)
+	if err != nil {
+		return err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return err
+	}
+	desired := &unstructured.Unstructured{Object: content}
+	desired.SetGroupVersionKind(gvk)
+	// not part of the desired state, claiming these fields would conflict with the API server
+	unstructured.RemoveNestedField(desired.Object, "status")
+	unstructured.RemoveNestedField(desired.Object, "metadata", "creationTimestamp")
+
+	opts := []client.ApplyOption{client.FieldOwner(fieldManager)}
+	if forceConflicts {
+		opts = append(opts, client.ForceOwnership)
+	}
+	if err := c.Apply(ctx, client.ApplyConfigurationFromUnstructured(desired), opts...); err != nil {
+		metrics.RecordApplyError(gvk)
+		return err
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(desired.Object, obj)
+}
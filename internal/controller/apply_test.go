@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+func TestApplyObject(t *testing.T) {
+	const fieldManager = "service-provider"
+	configMap := func(value string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Data:       map[string]string{"key": value},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		existing       client.Object
+		fieldOwner     string
+		forceConflicts bool
+		wantConflict   bool
+		wantValue      string
+	}{
+		{
+			name:      "new object",
+			wantValue: "desired",
+		},
+		{
+			name:       "only applied by the service provider",
+			existing:   configMap("previous"),
+			fieldOwner: fieldManager,
+			wantValue:  "desired",
+		},
+		{
+			name:         "field owned by an update of another manager",
+			existing:     configMap("edited"),
+			fieldOwner:   "kubectl-edit",
+			wantConflict: true,
+			wantValue:    "edited",
+		},
+		{
+			name:           "field owned by an update of another manager with forceConflicts",
+			existing:       configMap("edited"),
+			fieldOwner:     "kubectl-edit",
+			forceConflicts: true,
+			wantValue:      "desired",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := fake.NewClientBuilder().Build()
+			switch {
+			case tt.existing == nil:
+			case tt.fieldOwner == fieldManager:
+				if err := applyObject(ctx, c, tt.existing, fieldManager, false); err != nil {
+					t.Fatal(err)
+				}
+			default:
+				// created with an update operation, so that the other manager owns the data
+				if err := c.Create(ctx, tt.existing, client.FieldOwner(tt.fieldOwner)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := applyObject(ctx, c, configMap("desired"), fieldManager, tt.forceConflicts)
+			if apierrors.IsConflict(err) != tt.wantConflict || (err != nil && !tt.wantConflict) {
+				t.Fatalf("applyObject() error = %v, wantConflict %v", err, tt.wantConflict)
+			}
+			got := &corev1.ConfigMap{}
+			if err := c.Get(ctx, client.ObjectKey{Name: "test", Namespace: "default"}, got); err != nil {
+				t.Fatal(err)
+			}
+			if got.Data["key"] != tt.wantValue {
+				t.Errorf("data = %q, want %q", got.Data["key"], tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestGetForceConflicts(t *testing.T) {
+	var pc *apiv1alpha1.ProviderConfig
+	if pc.GetForceConflicts() {
+		t.Errorf("GetForceConflicts() of nil ProviderConfig = true")
+	}
+	pc = &apiv1alpha1.ProviderConfig{Spec: apiv1alpha1.ProviderConfigSpec{ForceConflicts: true}}
+	if !pc.GetForceConflicts() {
+		t.Errorf("GetForceConflicts() = false")
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package controller
+
+// opencontrolplane-gen:if SAMPLECODE=true
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// maxBlockingResources limits the number of user resources that are reported to block the deletion.
+const maxBlockingResources = 10
+
+// listUserResources lists the user resources of the given CRDs that remain in the MCP cluster
+// and returns them together with the kinds they belong to. CRDs that are not installed are skipped.
+// The resources are listed in the storage version of the installed CRD, which is served by any version of the domain service.
+func listUserResources(ctx context.Context, c client.Client, crds []*apiextensionsv1.CustomResourceDefinition) ([]unstructured.Unstructured, []string, error) {
+	var items []unstructured.Unstructured
+	var kinds []string
+	for _, crd := range crds {
+		installed := &apiextensionsv1.CustomResourceDefinition{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(crd), installed); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, nil, fmt.Errorf("unable to get CRD %s: %w", crd.Name, err)
+		}
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(schema.GroupVersionKind{
+			Group:   installed.Spec.Group,
+			Version: servedVersion(installed),
+			Kind:    installed.Spec.Names.ListKind,
+		})
+		if err := c.List(ctx, list); err != nil {
+			if meta.IsNoMatchError(err) {
+				continue
+			}
+			return nil, nil, fmt.Errorf("unable to list %s resources: %w", installed.Spec.Names.Kind, err)
+		}
+		if len(list.Items) > 0 {
+			items = append(items, list.Items...)
+			kinds = append(kinds, installed.Spec.Names.Kind)
+		}
+	}
+	return items, kinds, nil
+}
+
+// blockingResources returns references to the first user resources that block the deletion, sorted by namespace and name.
+func blockingResources(items []unstructured.Unstructured) []apiv1alpha1.ResourceReference {
+	refs := make([]apiv1alpha1.ResourceReference, 0, len(items))
+	for _, item := range items {
+		refs = append(refs, apiv1alpha1.ResourceReference{
+			APIVersion: item.GetAPIVersion(),
+			Kind:       item.GetKind(),
+			Namespace:  item.GetNamespace(),
+			Name:       item.GetName(),
+		})
+	}
+	slices.SortFunc(refs, func(a, b apiv1alpha1.ResourceReference) int {
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	if len(refs) > maxBlockingResources {
+		refs = refs[:maxBlockingResources]
+	}
+	return refs
+}
+
+// blockingMessage describes the user resources of the given kinds that block the deletion,
+// e.g. "kind Foo: 3 (default/a, default/b, default/c)".
+func blockingMessage(kinds []string, total int, refs []apiv1alpha1.ResourceReference) string {
+	names := make([]string, 0, len(refs)+1)
+	for _, ref := range refs {
+		names = append(names, ref.String())
+	}
+	if more := total - len(refs); more > 0 {
+		names = append(names, fmt.Sprintf("and %d more", more))
+	}
+	return fmt.Sprintf("kind %s: %d (%s)", strings.Join(kinds, ", "), total, strings.Join(names, ", "))
+}
+
+// forceDeleteUserResources deletes the user resources that remain in the MCP cluster for the ForceDelete deletion policy.
+// Finalizers of user resources that still exist after the grace period of the service object are removed.
+// opencontrolplane-gen:replace Foo=KIND
+func forceDeleteUserResources(ctx context.Context, c client.Client, obj *apiv1alpha1.Foo, items []unstructured.Unstructured) error {
+	gracePeriodOver := obj.DeletionTimestamp != nil && time.Since(obj.DeletionTimestamp.Time) > obj.GetForceDeleteGracePeriod()
+	for i := range items {
+		item := &items[i]
+		if item.GetDeletionTimestamp() == nil {
+			if err := c.Delete(ctx, item); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("unable to delete %s %s/%s: %w", item.GetKind(), item.GetNamespace(), item.GetName(), err)
+			}
+			continue
+		}
+		if gracePeriodOver && len(item.GetFinalizers()) > 0 {
+			patch := client.MergeFrom(item.DeepCopy())
+			item.SetFinalizers(nil)
+			if err := c.Patch(ctx, item, patch); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("unable to remove finalizers of %s %s/%s: %w", item.GetKind(), item.GetNamespace(), item.GetName(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// opencontrolplane-gen:fi
@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+// opencontrolplane-gen:if SAMPLECODE=true
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// testWidgetGVK is the kind of the user resources in the deletion tests.
+var testWidgetGVK = schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+
+func testWidget(namespace, name string, finalizers ...string) unstructured.Unstructured {
+	u := unstructured.Unstructured{}
+	u.SetGroupVersionKind(testWidgetGVK)
+	u.SetNamespace(namespace)
+	u.SetName(name)
+	u.SetFinalizers(finalizers)
+	return u
+}
+
+func testWidgetRef(namespace, name string) apiv1alpha1.ResourceReference {
+	return apiv1alpha1.ResourceReference{APIVersion: "example.com/v1", Kind: "Widget", Namespace: namespace, Name: name}
+}
+
+func TestBlockingResources(t *testing.T) {
+	many := make([]unstructured.Unstructured, 0, maxBlockingResources+2)
+	want := make([]apiv1alpha1.ResourceReference, 0, maxBlockingResources)
+	for i := maxBlockingResources + 1; i >= 0; i-- {
+		many = append(many, testWidget("default", fmt.Sprintf("widget-%02d", i)))
+	}
+	for i := range maxBlockingResources {
+		want = append(want, testWidgetRef("default", fmt.Sprintf("widget-%02d", i)))
+	}
+	tests := []struct {
+		name  string
+		items []unstructured.Unstructured
+		want  []apiv1alpha1.ResourceReference
+	}{
+		{name: "no user resources", want: []apiv1alpha1.ResourceReference{}},
+		{
+			name:  "sorted by namespace and name",
+			items: []unstructured.Unstructured{testWidget("b", "a"), testWidget("a", "b"), testWidget("", "c"), testWidget("a", "a")},
+			want: []apiv1alpha1.ResourceReference{
+				testWidgetRef("", "c"), testWidgetRef("a", "a"), testWidgetRef("a", "b"), testWidgetRef("b", "a"),
+			},
+		},
+		{name: "limited to the first resources", items: many, want: want},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blockingResources(tt.items); !equality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("blockingResources() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlockingMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		kinds []string
+		total int
+		refs  []apiv1alpha1.ResourceReference
+		want  string
+	}{
+		{
+			name:  "all resources listed",
+			kinds: []string{"Widget"},
+			total: 2,
+			refs:  []apiv1alpha1.ResourceReference{testWidgetRef("default", "a"), testWidgetRef("", "b")},
+			want:  "kind Widget: 2 (default/a, b)",
+		},
+		{
+			name:  "remaining resources counted",
+			kinds: []string{"Gadget", "Widget"},
+			total: 5,
+			refs:  []apiv1alpha1.ResourceReference{testWidgetRef("default", "a")},
+			want:  "kind Gadget, Widget: 5 (default/a, and 4 more)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blockingMessage(tt.kinds, tt.total, tt.refs); got != tt.want {
+				t.Errorf("blockingMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForceDeleteUserResources(t *testing.T) {
+	tests := []struct {
+		name string
+		// deletedSince is the time since the deletion of the service object
+		deletedSince   time.Duration
+		wantFinalizers bool
+	}{
+		{name: "finalizers are kept during the grace period", deletedSince: time.Minute, wantFinalizers: true},
+		{name: "finalizers are removed after the grace period", deletedSince: apiv1alpha1.DefaultForceDeleteGracePeriod + time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			scheme := runtime.NewScheme()
+			scheme.AddKnownTypeWithName(testWidgetGVK, &unstructured.Unstructured{})
+			scheme.AddKnownTypeWithName(testWidgetGVK.GroupVersion().WithKind("WidgetList"), &unstructured.UnstructuredList{})
+			plain, finalized := testWidget("default", "plain"), testWidget("default", "finalized", "example.com/cleanup")
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&plain, &finalized).Build()
+
+			// opencontrolplane-gen:replace Foo=KIND
+			obj := &apiv1alpha1.Foo{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &metav1.Time{Time: time.Now().Add(-tt.deletedSince)}}}
+			items := []unstructured.Unstructured{plain, finalized}
+			// the first call deletes the user resources, the second one removes the finalizers after the grace period
+			for range 2 {
+				if err := forceDeleteUserResources(ctx, c, obj, items); err != nil {
+					t.Fatalf("forceDeleteUserResources() error = %v", err)
+				}
+				list := &unstructured.UnstructuredList{}
+				list.SetGroupVersionKind(testWidgetGVK.GroupVersion().WithKind("WidgetList"))
+				if err := c.List(ctx, list); err != nil {
+					t.Fatal(err)
+				}
+				items = list.Items
+			}
+
+			gone := &unstructured.Unstructured{}
+			gone.SetGroupVersionKind(testWidgetGVK)
+			if err := c.Get(ctx, client.ObjectKeyFromObject(&plain), gone); !apierrors.IsNotFound(err) {
+				t.Errorf("user resource without finalizers still exists, error = %v", err)
+			}
+			remaining := &unstructured.Unstructured{}
+			remaining.SetGroupVersionKind(testWidgetGVK)
+			err := c.Get(ctx, client.ObjectKeyFromObject(&finalized), remaining)
+			if exists := err == nil; exists != tt.wantFinalizers {
+				t.Errorf("user resource with finalizers exists = %v, want %v (error = %v)", exists, tt.wantFinalizers, err)
+			}
+			if tt.wantFinalizers && remaining.GetDeletionTimestamp() == nil {
+				t.Errorf("user resource with finalizers has not been deleted")
+			}
+		})
+	}
+}
+
+// opencontrolplane-gen:fi
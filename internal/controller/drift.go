@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package controller
+
+import (
+	// opencontrolplane-gen:if SAMPLECODE=true
+	"context"
+	"strconv"
+
+	ctrlutils "github.com/openmcp-project/controller-utils/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	// opencontrolplane-gen:fi
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// pollJitterFactor spreads periodic reconciles of many objects over time.
+const pollJitterFactor = 0.1
+
+// opencontrolplane-gen:if SAMPLECODE=true
+// appliedGenerationAnnotation records the generation of a managed object after it was last applied by the service provider.
+// A different generation on the next reconcile means that the object has been modified by someone else.
+var appliedGenerationAnnotation = apiv1alpha1.GroupVersion.Group + "/applied-generation"
+
+// opencontrolplane-gen:fi
+
+// pollResult requeues the object after the poll interval of the ProviderConfig to detect drift of managed objects.
+func pollResult(pc *apiv1alpha1.ProviderConfig) ctrl.Result {
+	return ctrl.Result{RequeueAfter: wait.Jitter(pc.PollInterval(), pollJitterFactor)}
+}
+
+// opencontrolplane-gen:if SAMPLECODE=true
+// hasDrifted returns true if the managed object has been modified since it was last applied by the service provider.
+// Objects that have not been applied yet never count as drifted.
+func hasDrifted(obj client.Object) bool {
+	applied, ok := ctrlutils.GetAnnotation(obj, appliedGenerationAnnotation)
+	return ok && applied != strconv.FormatInt(obj.GetGeneration(), 10)
+}
+
+// markApplied records the current generation of the managed object on the object itself.
+func markApplied(ctx context.Context, c client.Client, obj client.Object) error {
+	return ctrlutils.EnsureAnnotation(ctx, c, obj, appliedGenerationAnnotation, strconv.FormatInt(obj.GetGeneration(), 10), true, ctrlutils.OVERWRITE)
+}
+
+// opencontrolplane-gen:fi
@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	// opencontrolplane-gen:if SAMPLECODE=true
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	// opencontrolplane-gen:fi
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+func TestPollResult(t *testing.T) {
+	tests := []struct {
+		name string
+		pc   *apiv1alpha1.ProviderConfig
+		want time.Duration
+	}{
+		{name: "no ProviderConfig", want: apiv1alpha1.DefaultPollInterval},
+		{name: "default poll interval", pc: &apiv1alpha1.ProviderConfig{}, want: apiv1alpha1.DefaultPollInterval},
+		{
+			name: "custom poll interval",
+			pc:   &apiv1alpha1.ProviderConfig{Spec: apiv1alpha1.ProviderConfigSpec{PollInterval: &metav1.Duration{Duration: 5 * time.Minute}}},
+			want: 5 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pollResult(tt.pc).RequeueAfter
+			if maxJittered := time.Duration(float64(tt.want) * (1 + pollJitterFactor)); got < tt.want || got > maxJittered {
+				t.Errorf("RequeueAfter = %s, want between %s and %s", got, tt.want, maxJittered)
+			}
+		})
+	}
+}
+
+// opencontrolplane-gen:if SAMPLECODE=true
+func TestDriftDetection(t *testing.T) {
+	ctx := context.Background()
+	obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "managed", Namespace: "default", Generation: 3}}
+	c := fake.NewClientBuilder().WithObjects(obj).Build()
+	if hasDrifted(obj) {
+		t.Error("object that has not been applied yet has drifted")
+	}
+
+	if err := markApplied(ctx, c, obj); err != nil {
+		t.Fatalf("markApplied() error = %v", err)
+	}
+	applied := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), applied); err != nil {
+		t.Fatal(err)
+	}
+	if got := applied.Annotations[appliedGenerationAnnotation]; got != "3" {
+		t.Errorf("applied generation annotation = %q, want %q", got, "3")
+	}
+	if hasDrifted(applied) {
+		t.Error("object has drifted right after it has been applied")
+	}
+
+	// a modification by someone else increases the generation
+	applied.Generation++
+	if !hasDrifted(applied) {
+		t.Error("modified object has not drifted")
+	}
+}
+
+// opencontrolplane-gen:fi
@@ -0,0 +1,163 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	// opencontrolplane-gen:if SAMPLECODE=true
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	// opencontrolplane-gen:fi
+
+	clusteraccess "github.com/openmcp-project/opencontrolplane-runtime/pkg/serviceprovider/clusteraccess"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// Event reasons recorded on service objects.
+const (
+	eventReasonAccessGranted     = "AccessGranted"
+	eventReasonDeletionCompleted = "DeletionCompleted"
+	// opencontrolplane-gen:if SAMPLECODE=true
+	eventReasonApplied         = "ManagedResourceApplied"
+	eventReasonUpdated         = "ManagedResourceUpdated"
+	eventReasonDriftCorrected  = "DriftCorrected"
+	eventReasonDeletionBlocked = "DeletionBlocked"
+	eventReasonFieldConflict   = "FieldConflict"
+	// opencontrolplane-gen:fi
+)
+
+// Event actions recorded on service objects.
+const (
+	eventActionAccess = "Access"
+	eventActionDelete = "Delete"
+	// opencontrolplane-gen:if SAMPLECODE=true
+	eventActionApply   = "Apply"
+	eventActionReapply = "Reapply"
+	// opencontrolplane-gen:fi
+)
+
+// opencontrolplane-gen:replace Foo=KIND
+// normalEvent records an event of type Normal if an event recorder is configured.
+// opencontrolplane-gen:replace Foo=KIND
+func (r *FooReconciler) normalEvent(regarding, related runtime.Object, reason, action, note string, args ...any) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(regarding, related, corev1.EventTypeNormal, reason, action, note, args...)
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// mcpAccessGranted reports whether access to the MCP cluster has been granted
+// and records an event when access has been granted since the last reconcile.
+// opencontrolplane-gen:replace Foo=KIND
+func (r *FooReconciler) mcpAccessGranted(obj *apiv1alpha1.Foo, clusters clusteraccess.ClusterContext) bool {
+	wasGranted := meta.IsStatusConditionTrue(obj.Status.Conditions, apiv1alpha1.ConditionTypeMCPAccessReady)
+	granted := mcpAccessReady(obj, clusters)
+	if granted && !wasGranted {
+		r.normalEvent(obj, nil, eventReasonAccessGranted, eventActionAccess, "access to the MCP cluster has been granted")
+	}
+	return granted
+}
+
+// opencontrolplane-gen:if SAMPLECODE=true
+// opencontrolplane-gen:replace Foo=KIND
+// warningEvent records an event of type Warning if an event recorder is configured.
+// opencontrolplane-gen:replace Foo=KIND
+func (r *FooReconciler) warningEvent(regarding, related runtime.Object, reason, action, note string, args ...any) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(regarding, related, corev1.EventTypeWarning, reason, action, note, args...)
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// managedObjectEvent records whether the managed CRD has been created, updated or reapplied after drift.
+// existing is the state before the apply, managedObj the state after the apply. Nothing is recorded if the apply did not change the spec.
+// opencontrolplane-gen:replace Foo=KIND
+func (r *FooReconciler) managedObjectEvent(obj *apiv1alpha1.Foo, existing, managedObj *apiextensionsv1.CustomResourceDefinition, drifted bool) {
+	switch {
+	case drifted:
+		r.warningEvent(obj, managedObj, eventReasonDriftCorrected, eventActionReapply,
+			"CustomResourceDefinition %s was modified on the MCP cluster and has been reapplied", managedObj.Name)
+	case existing.ResourceVersion == "":
+		r.normalEvent(obj, managedObj, eventReasonApplied, eventActionApply,
+			"CustomResourceDefinition %s has been applied to the MCP cluster", managedObj.Name)
+	case existing.Generation != managedObj.Generation:
+		r.normalEvent(obj, managedObj, eventReasonUpdated, eventActionApply,
+			"CustomResourceDefinition %s has been updated on the MCP cluster", managedObj.Name)
+	}
+}
+
+// opencontrolplane-gen:fi
+
+// DeduplicatingRecorder suppresses events that have been recorded for the same object
+// with the same type, reason and note within the window, so that requeues do not flood the event stream.
+type DeduplicatingRecorder struct {
+	recorder events.EventRecorder
+	window   time.Duration
+	now      func() time.Time
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+var _ events.EventRecorder = &DeduplicatingRecorder{}
+
+// NewDeduplicatingRecorder returns a recorder that forwards events to recorder unless they are duplicates within the window.
+func NewDeduplicatingRecorder(recorder events.EventRecorder, window time.Duration) *DeduplicatingRecorder {
+	return &DeduplicatingRecorder{recorder: recorder, window: window, now: time.Now, seen: map[string]time.Time{}}
+}
+
+// Eventf implements events.EventRecorder.
+func (d *DeduplicatingRecorder) Eventf(regarding, related runtime.Object, eventtype, reason, action, note string, args ...any) {
+	key := eventKey(regarding, eventtype, reason, fmt.Sprintf(note, args...))
+	now := d.now()
+	d.mu.Lock()
+	for k, t := range d.seen {
+		if now.Sub(t) > d.window {
+			delete(d.seen, k)
+		}
+	}
+	_, duplicate := d.seen[key]
+	if !duplicate {
+		d.seen[key] = now
+	}
+	d.mu.Unlock()
+	if duplicate {
+		return
+	}
+	d.recorder.Eventf(regarding, related, eventtype, reason, action, note, args...)
+}
+
+// eventKey identifies an event by the UID of the regarding object, its type, reason and note.
+func eventKey(regarding runtime.Object, eventtype, reason, note string) string {
+	id := fmt.Sprintf("%p", regarding)
+	if acc, err := meta.Accessor(regarding); err == nil {
+		id = string(acc.GetUID())
+	}
+	return id + "/" + eventtype + "/" + reason + "/" + note
+}
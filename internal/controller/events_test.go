@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+)
+
+func TestDeduplicatingRecorder(t *testing.T) {
+	const window = 10 * time.Minute
+	first := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "first", UID: types.UID("first")}}
+	second := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "second", UID: types.UID("second")}}
+
+	type event struct {
+		// after is the time since the first event.
+		after     time.Duration
+		regarding *corev1.ConfigMap
+		reason    string
+		note      string
+		want      bool
+	}
+	tests := []struct {
+		name   string
+		events []event
+	}{
+		{
+			name: "duplicates within the window are suppressed",
+			events: []event{
+				{regarding: first, reason: "Applied", note: "applied", want: true},
+				{after: time.Minute, regarding: first, reason: "Applied", note: "applied"},
+				{after: window, regarding: first, reason: "Applied", note: "applied"},
+			},
+		},
+		{
+			name: "duplicates are recorded again after the window",
+			events: []event{
+				{regarding: first, reason: "Applied", note: "applied", want: true},
+				{after: window + time.Second, regarding: first, reason: "Applied", note: "applied", want: true},
+				{after: window + time.Minute, regarding: first, reason: "Applied", note: "applied"},
+			},
+		},
+		{
+			name: "events with another object, reason or note are recorded",
+			events: []event{
+				{regarding: first, reason: "Applied", note: "applied", want: true},
+				{regarding: second, reason: "Applied", note: "applied", want: true},
+				{regarding: first, reason: "Updated", note: "applied", want: true},
+				{regarding: first, reason: "Applied", note: "reapplied", want: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			var now time.Time
+			fake := events.NewFakeRecorder(len(tt.events))
+			recorder := NewDeduplicatingRecorder(fake, window)
+			recorder.now = func() time.Time { return now }
+			for i, e := range tt.events {
+				now = start.Add(e.after)
+				recorder.Eventf(e.regarding, nil, corev1.EventTypeNormal, e.reason, "Apply", e.note)
+				select {
+				case got := <-fake.Events:
+					if !e.want {
+						t.Errorf("event %d: recorded %q, want it to be suppressed", i, got)
+					}
+				default:
+					if e.want {
+						t.Errorf("event %d: suppressed, want it to be recorded", i)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	clusteraccess "github.com/openmcp-project/opencontrolplane-runtime/pkg/serviceprovider/clusteraccess"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// inventory collects the objects that are applied during a reconciliation.
+type inventory struct {
+	entries []apiv1alpha1.InventoryEntry
+}
+
+// add records an object that has been applied to the given cluster.
+func (inv *inventory) add(c client.Client, cluster apiv1alpha1.InventoryCluster, obj client.Object) error {
+	gvk, err := c.GroupVersionKindFor(obj)
+	if err != nil {
+		return err
+	}
+	inv.entries = append(inv.entries, apiv1alpha1.InventoryEntry{
+		Cluster:   cluster,
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		UID:       obj.GetUID(),
+	})
+	return nil
+}
+
+// inventoryClients returns the clients for the clusters that inventory entries can belong to.
+func inventoryClients(clusters clusteraccess.ClusterContext) map[apiv1alpha1.InventoryCluster]client.Client {
+	clients := map[apiv1alpha1.InventoryCluster]client.Client{}
+	if clusters.MCPCluster != nil {
+		clients[apiv1alpha1.InventoryClusterMCP] = clusters.MCPCluster.Client()
+	}
+	if clusters.WorkloadCluster != nil {
+		clients[apiv1alpha1.InventoryClusterWorkload] = clusters.WorkloadCluster.Client()
+	}
+	return clients
+}
+
+// sameObject returns true if both entries refer to the same object, independent of the version and UID.
+func sameObject(a, b apiv1alpha1.InventoryEntry) bool {
+	return a.Cluster == b.Cluster && a.Group == b.Group && a.Kind == b.Kind && a.Namespace == b.Namespace && a.Name == b.Name
+}
+
+// pruneInventory deletes the objects of the previous inventory that are not part of the current one.
+// CRDs that still have instances are not deleted, as this would delete the user resources as well.
+// Their entries are returned, so that they stay in the inventory until the instances are gone.
+func pruneInventory(ctx context.Context, previous, current []apiv1alpha1.InventoryEntry, clients map[apiv1alpha1.InventoryCluster]client.Client) ([]apiv1alpha1.InventoryEntry, error) {
+	var inUse []apiv1alpha1.InventoryEntry
+	for _, entry := range previous {
+		if slices.ContainsFunc(current, func(e apiv1alpha1.InventoryEntry) bool { return sameObject(e, entry) }) {
+			continue
+		}
+		hasInstances, err := crdHasInstances(ctx, entry, clients)
+		if err != nil {
+			return inUse, err
+		}
+		if hasInstances {
+			inUse = append(inUse, entry)
+			continue
+		}
+		if _, err := deleteInventoryEntry(ctx, entry, clients); err != nil {
+			return inUse, err
+		}
+	}
+	return inUse, nil
+}
+
+// crdHasInstances returns true if the entry refers to a CRD of which objects exist.
+func crdHasInstances(ctx context.Context, entry apiv1alpha1.InventoryEntry, clients map[apiv1alpha1.InventoryCluster]client.Client) (bool, error) {
+	c, ok := clients[entry.Cluster]
+	if !ok || entry.Group != apiextensionsv1.GroupName || entry.Kind != "CustomResourceDefinition" {
+		return false, nil
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := c.Get(ctx, client.ObjectKey{Name: entry.Name}, crd); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if entry.UID != "" && crd.UID != entry.UID {
+		return false, nil
+	}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   crd.Spec.Group,
+		Version: servedVersion(crd),
+		Kind:    crd.Spec.Names.ListKind,
+	})
+	if err := c.List(ctx, list, client.Limit(1)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to list %s resources: %w", crd.Spec.Names.Kind, err)
+	}
+	return len(list.Items) > 0, nil
+}
+
+// servedVersion returns the storage version of crd if it is served, otherwise the first served version.
+func servedVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	served := ""
+	for _, v := range crd.Spec.Versions {
+		if !v.Served {
+			continue
+		}
+		if v.Storage {
+			return v.Name
+		}
+		if served == "" {
+			served = v.Name
+		}
+	}
+	return served
+}
+
+// inUseMessage describes the CRDs that are kept because instances of them remain.
+func inUseMessage(entries []apiv1alpha1.InventoryEntry) string {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+	return fmt.Sprintf("CustomResourceDefinitions %s are no longer managed but still have instances, they are deleted once the instances are gone",
+		strings.Join(names, ", "))
+}
+
+// deleteInventory deletes all objects of the inventory in reverse order of their creation.
+// It returns the entries of the objects that still exist, on error together with the entries that have not been processed.
+func deleteInventory(ctx context.Context, entries []apiv1alpha1.InventoryEntry, clients map[apiv1alpha1.InventoryCluster]client.Client) ([]apiv1alpha1.InventoryEntry, error) {
+	var remaining []apiv1alpha1.InventoryEntry
+	for i := len(entries) - 1; i >= 0; i-- {
+		gone, err := deleteInventoryEntry(ctx, entries[i], clients)
+		if err != nil {
+			// the capacity limit keeps the caller's entries from being overwritten by the append
+			return append(entries[:i+1:i+1], remaining...), err
+		}
+		if !gone {
+			remaining = append([]apiv1alpha1.InventoryEntry{entries[i]}, remaining...)
+		}
+	}
+	return remaining, nil
+}
+
+// deleteInventoryEntry deletes the object of an inventory entry and returns true if the object is gone.
+// Objects that have been recreated by someone else since they were applied are left untouched.
+func deleteInventoryEntry(ctx context.Context, entry apiv1alpha1.InventoryEntry, clients map[apiv1alpha1.InventoryCluster]client.Client) (bool, error) {
+	c, ok := clients[entry.Cluster]
+	if !ok {
+		return false, fmt.Errorf("no access to %s cluster to delete %s %s", entry.Cluster, entry.Kind, entry.Name)
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(entry.GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKey{Namespace: entry.Namespace, Name: entry.Name}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("unable to get %s %s: %w", entry.Kind, entry.Name, err)
+	}
+	if entry.UID != "" && obj.GetUID() != entry.UID {
+		return true, nil
+	}
+	if obj.GetDeletionTimestamp() != nil {
+		return false, nil
+	}
+	uid := obj.GetUID()
+	if err := c.Delete(ctx, obj, client.Preconditions{UID: &uid}); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("unable to delete %s %s: %w", entry.Kind, entry.Name, err)
+	}
+	return false, nil
+}
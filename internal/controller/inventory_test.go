@@ -0,0 +1,318 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// errDeleteFailed is returned by the test clients for objects whose deletion fails.
+var errDeleteFailed = errors.New("delete failed")
+
+func testConfigMap(name string, uid types.UID, finalizers ...string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: uid, Finalizers: finalizers}}
+}
+
+func testEntry(cluster apiv1alpha1.InventoryCluster, name string, uid types.UID) apiv1alpha1.InventoryEntry {
+	return apiv1alpha1.InventoryEntry{Cluster: cluster, Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: name, UID: uid}
+}
+
+// testInventoryClient returns a fake client with the given objects that fails to delete the objects named failDelete.
+func testInventoryClient(t *testing.T, failDelete string, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			if obj.GetName() == failDelete {
+				return errDeleteFailed
+			}
+			return c.Delete(ctx, obj, opts...)
+		},
+	}).Build()
+}
+
+func TestInventoryAdd(t *testing.T) {
+	c := testInventoryClient(t, "")
+	inv := &inventory{}
+	if err := inv.add(c, apiv1alpha1.InventoryClusterMCP, testConfigMap("a", "uid-a")); err != nil {
+		t.Fatalf("add() error = %v", err)
+	}
+	if err := inv.add(c, apiv1alpha1.InventoryClusterWorkload, testConfigMap("b", "uid-b")); err != nil {
+		t.Fatalf("add() error = %v", err)
+	}
+	want := []apiv1alpha1.InventoryEntry{
+		testEntry(apiv1alpha1.InventoryClusterMCP, "a", "uid-a"),
+		testEntry(apiv1alpha1.InventoryClusterWorkload, "b", "uid-b"),
+	}
+	if !equality.Semantic.DeepEqual(inv.entries, want) {
+		t.Errorf("entries = %+v, want %+v", inv.entries, want)
+	}
+	if err := inv.add(c, apiv1alpha1.InventoryClusterMCP, &apiv1alpha1.ProviderConfig{}); err == nil {
+		t.Errorf("add() of kind unknown to the client succeeded")
+	}
+}
+
+func TestPruneInventory(t *testing.T) {
+	mcp := apiv1alpha1.InventoryClusterMCP
+	tests := []struct {
+		name              string
+		objs              []client.Object
+		previous, current []apiv1alpha1.InventoryEntry
+		wantExisting      []string
+		wantErr           bool
+	}{
+		{
+			name:         "objects of the current inventory are kept",
+			objs:         []client.Object{testConfigMap("a", "uid-a"), testConfigMap("b", "uid-b")},
+			previous:     []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a"), testEntry(mcp, "b", "uid-b")},
+			current:      []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a"), testEntry(mcp, "b", "uid-b")},
+			wantExisting: []string{"a", "b"},
+		},
+		{
+			name:         "objects no longer in the inventory are deleted",
+			objs:         []client.Object{testConfigMap("a", "uid-a"), testConfigMap("b", "uid-b")},
+			previous:     []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a"), testEntry(mcp, "b", "uid-b")},
+			current:      []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a")},
+			wantExisting: []string{"a"},
+		},
+		{
+			name:         "objects with a different version are the same object",
+			objs:         []client.Object{testConfigMap("a", "uid-a")},
+			previous:     []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a")},
+			current:      []apiv1alpha1.InventoryEntry{func() apiv1alpha1.InventoryEntry { e := testEntry(mcp, "a", "uid-b"); e.Version = "v2"; return e }()},
+			wantExisting: []string{"a"},
+		},
+		{
+			name:         "recreated objects are not deleted",
+			objs:         []client.Object{testConfigMap("a", "uid-new")},
+			previous:     []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a")},
+			wantExisting: []string{"a"},
+		},
+		{
+			name:         "objects moved to another cluster are deleted",
+			objs:         []client.Object{testConfigMap("a", "uid-a")},
+			previous:     []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a")},
+			current:      []apiv1alpha1.InventoryEntry{testEntry(apiv1alpha1.InventoryClusterWorkload, "a", "uid-a")},
+			wantExisting: []string{},
+		},
+		{
+			name:         "failed deletion is returned",
+			objs:         []client.Object{testConfigMap("fail", "uid-fail")},
+			previous:     []apiv1alpha1.InventoryEntry{testEntry(mcp, "fail", "uid-fail")},
+			wantExisting: []string{"fail"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testInventoryClient(t, "fail", tt.objs...)
+			inUse, err := pruneInventory(context.Background(), tt.previous, tt.current, map[apiv1alpha1.InventoryCluster]client.Client{mcp: c})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("pruneInventory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(inUse) != 0 {
+				t.Errorf("pruneInventory() in use = %+v, want none", inUse)
+			}
+			for _, name := range []string{"a", "b", "fail"} {
+				err := c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, &corev1.ConfigMap{})
+				if err != nil && !apierrors.IsNotFound(err) {
+					t.Fatal(err)
+				}
+				if exists, want := err == nil, slices.Contains(tt.wantExisting, name); exists != want {
+					t.Errorf("object %s exists = %v, want %v", name, exists, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPruneInventoryCRDs(t *testing.T) {
+	mcp := apiv1alpha1.InventoryClusterMCP
+	gv := schema.GroupVersion{Group: "example.com", Version: "v1"}
+	crdEntry := apiv1alpha1.InventoryEntry{Cluster: mcp, Group: apiextensionsv1.GroupName, Version: "v1", Kind: "CustomResourceDefinition", Name: "widgets.example.com", UID: "uid-crd"}
+	tests := []struct {
+		name       string
+		instances  int
+		wantInUse  []apiv1alpha1.InventoryEntry
+		wantExists bool
+	}{
+		{
+			name:       "CRDs with instances are kept",
+			instances:  2,
+			wantInUse:  []apiv1alpha1.InventoryEntry{crdEntry},
+			wantExists: true,
+		},
+		{
+			name: "CRDs without instances are deleted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := apiextensionsv1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+			scheme.AddKnownTypeWithName(gv.WithKind("Widget"), &unstructured.Unstructured{})
+			scheme.AddKnownTypeWithName(gv.WithKind("WidgetList"), &unstructured.UnstructuredList{})
+			objs := []client.Object{&apiextensionsv1.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com", UID: "uid-crd"},
+				Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+					Group:    "example.com",
+					Names:    apiextensionsv1.CustomResourceDefinitionNames{Kind: "Widget", ListKind: "WidgetList", Plural: "widgets"},
+					Scope:    apiextensionsv1.NamespaceScoped,
+					Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1", Served: true, Storage: true}},
+				},
+			}}
+			for i := range tt.instances {
+				widget := &unstructured.Unstructured{}
+				widget.SetGroupVersionKind(gv.WithKind("Widget"))
+				widget.SetNamespace("default")
+				widget.SetName(fmt.Sprintf("widget-%d", i))
+				objs = append(objs, widget)
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+			inUse, err := pruneInventory(context.Background(), []apiv1alpha1.InventoryEntry{crdEntry}, nil, map[apiv1alpha1.InventoryCluster]client.Client{mcp: c})
+			if err != nil {
+				t.Fatalf("pruneInventory() error = %v", err)
+			}
+			if !equality.Semantic.DeepEqual(inUse, tt.wantInUse) {
+				t.Errorf("pruneInventory() in use = %+v, want %+v", inUse, tt.wantInUse)
+			}
+			err = c.Get(context.Background(), client.ObjectKey{Name: "widgets.example.com"}, &apiextensionsv1.CustomResourceDefinition{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				t.Fatal(err)
+			}
+			if exists := err == nil; exists != tt.wantExists {
+				t.Errorf("CRD exists = %v, want %v", exists, tt.wantExists)
+			}
+		})
+	}
+}
+
+func TestDeleteInventory(t *testing.T) {
+	mcp, workload := apiv1alpha1.InventoryClusterMCP, apiv1alpha1.InventoryClusterWorkload
+	tests := []struct {
+		name    string
+		objs    []client.Object
+		entries []apiv1alpha1.InventoryEntry
+		// clusters lists the clusters the reconciler has access to
+		clusters []apiv1alpha1.InventoryCluster
+		// wantFirst and wantSecond are the remaining entries after the first and second call
+		wantFirst, wantSecond []apiv1alpha1.InventoryEntry
+		wantErr               bool
+	}{
+		{
+			name:       "empty inventory",
+			clusters:   []apiv1alpha1.InventoryCluster{mcp},
+			wantFirst:  nil,
+			wantSecond: nil,
+		},
+		{
+			name:       "objects remain until they are gone",
+			objs:       []client.Object{testConfigMap("a", "uid-a"), testConfigMap("b", "uid-b")},
+			entries:    []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a"), testEntry(mcp, "b", "uid-b")},
+			clusters:   []apiv1alpha1.InventoryCluster{mcp},
+			wantFirst:  []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a"), testEntry(mcp, "b", "uid-b")},
+			wantSecond: nil,
+		},
+		{
+			name:       "objects with finalizers remain",
+			objs:       []client.Object{testConfigMap("a", "uid-a", "example.com/finalizer"), testConfigMap("b", "uid-b")},
+			entries:    []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a"), testEntry(mcp, "b", "uid-b")},
+			clusters:   []apiv1alpha1.InventoryCluster{mcp},
+			wantFirst:  []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a"), testEntry(mcp, "b", "uid-b")},
+			wantSecond: []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a")},
+		},
+		{
+			name:       "objects that are gone or recreated are dropped",
+			objs:       []client.Object{testConfigMap("b", "uid-new")},
+			entries:    []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a"), testEntry(mcp, "b", "uid-b")},
+			clusters:   []apiv1alpha1.InventoryCluster{mcp},
+			wantFirst:  nil,
+			wantSecond: nil,
+		},
+		{
+			name:       "unprocessed and remaining entries are kept on error",
+			objs:       []client.Object{testConfigMap("a", "uid-a"), testConfigMap("fail", "uid-fail"), testConfigMap("b", "uid-b")},
+			entries:    []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a"), testEntry(mcp, "fail", "uid-fail"), testEntry(mcp, "b", "uid-b")},
+			clusters:   []apiv1alpha1.InventoryCluster{mcp},
+			wantFirst:  []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a"), testEntry(mcp, "fail", "uid-fail"), testEntry(mcp, "b", "uid-b")},
+			wantSecond: []apiv1alpha1.InventoryEntry{testEntry(mcp, "a", "uid-a"), testEntry(mcp, "fail", "uid-fail")},
+			wantErr:    true,
+		},
+		{
+			name:       "entries of clusters without access are kept on error",
+			objs:       []client.Object{testConfigMap("a", "uid-a")},
+			entries:    []apiv1alpha1.InventoryEntry{testEntry(workload, "w", "uid-w"), testEntry(mcp, "a", "uid-a")},
+			clusters:   []apiv1alpha1.InventoryCluster{mcp},
+			wantFirst:  []apiv1alpha1.InventoryEntry{testEntry(workload, "w", "uid-w"), testEntry(mcp, "a", "uid-a")},
+			wantSecond: []apiv1alpha1.InventoryEntry{testEntry(workload, "w", "uid-w")},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testInventoryClient(t, "fail", tt.objs...)
+			clients := map[apiv1alpha1.InventoryCluster]client.Client{}
+			for _, cluster := range tt.clusters {
+				clients[cluster] = c
+			}
+			entries := append([]apiv1alpha1.InventoryEntry(nil), tt.entries...)
+			remaining, err := deleteInventory(context.Background(), entries, clients)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("deleteInventory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !equality.Semantic.DeepEqual(remaining, tt.wantFirst) {
+				t.Errorf("deleteInventory() remaining = %+v, want %+v", remaining, tt.wantFirst)
+			}
+			if !equality.Semantic.DeepEqual(entries, tt.entries) {
+				t.Errorf("deleteInventory() modified the entries of the caller: %+v", entries)
+			}
+			remaining, err = deleteInventory(context.Background(), remaining, clients)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("second deleteInventory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !equality.Semantic.DeepEqual(remaining, tt.wantSecond) {
+				t.Errorf("second deleteInventory() remaining = %+v, want %+v", remaining, tt.wantSecond)
+			}
+		})
+	}
+}
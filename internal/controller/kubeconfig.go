@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package controller
+
+// opencontrolplane-gen:if WORKLOADCLUSTER=true
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+
+	"github.com/openmcp-project/controller-utils/pkg/clusters"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// mcpKubeconfigSecretName is the name of the secret that contains the kubeconfig for the MCP cluster.
+	mcpKubeconfigSecretName = "mcp-kubeconfig"
+	// mcpKubeconfigKey is the key of the kubeconfig in the secret.
+	mcpKubeconfigKey = "kubeconfig"
+	// mcpTokenKey is the key of the token in the secret, the kubeconfig references it as token file.
+	mcpTokenKey = "token"
+	// mcpKubeconfigMountPath is the directory the secret is mounted to in the containers.
+	mcpKubeconfigMountPath = "/etc/mcp"
+	// mcpKubeconfigChecksumAnnotation records the checksum of the kubeconfig on pod templates.
+	mcpKubeconfigChecksumAnnotation = "openmcp.cloud/mcp-kubeconfig-checksum"
+)
+
+// MCPKubeconfig materialises the credentials of an access request for the MCP cluster of a service object
+// as a Secret in a namespace of the workload cluster and references it from the pods deployed there.
+// Use an access request that is dedicated to the workload, the credentials of the service provider must not leave it.
+//
+// The token is stored separately from the kubeconfig, which references it as token file.
+// When the access request refreshes the token, applying the secret again rotates it in the mounted file,
+// and clients built with client-go pick it up without a restart. Pods are only restarted if the endpoint
+// or the certificate authority of the MCP cluster change, as these are read once.
+type MCPKubeconfig struct {
+	secret   *corev1.Secret
+	checksum string
+}
+
+// NewMCPKubeconfig returns an MCPKubeconfig with the credentials of the MCP cluster for a secret in the given namespace.
+// Only token and client certificate credentials are supported.
+// The kubeconfig trusts the same certificate authorities as the REST config of the MCP cluster, including an additional CA bundle.
+func NewMCPKubeconfig(mcp *clusters.Cluster, namespace string) (*MCPKubeconfig, error) {
+	if mcp == nil || !mcp.HasRESTConfig() {
+		return nil, fmt.Errorf("access to the MCP cluster has not been granted yet")
+	}
+	cfg := rest.CopyConfig(mcp.RESTConfig())
+	if err := rest.LoadTLSFiles(cfg); err != nil {
+		return nil, fmt.Errorf("unable to load TLS files of the MCP cluster config: %w", err)
+	}
+	authInfo := &clientcmdapi.AuthInfo{
+		ClientCertificateData: cfg.CertData,
+		ClientKeyData:         cfg.KeyData,
+	}
+	data := map[string][]byte{}
+	if cfg.BearerToken != "" {
+		authInfo.TokenFile = path.Join(mcpKubeconfigMountPath, mcpTokenKey)
+		data[mcpTokenKey] = []byte(cfg.BearerToken)
+	}
+	if cfg.BearerToken == "" && len(cfg.CertData) == 0 {
+		return nil, fmt.Errorf("the MCP cluster config contains neither a token nor a client certificate")
+	}
+	kubeconfig, err := clientcmd.Write(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"mcp": {
+				Server:                   cfg.Host,
+				TLSServerName:            cfg.ServerName,
+				CertificateAuthorityData: cfg.CAData,
+				InsecureSkipTLSVerify:    cfg.Insecure,
+			},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{"mcp": authInfo},
+		Contexts: map[string]*clientcmdapi.Context{
+			"mcp": {Cluster: "mcp", AuthInfo: "mcp"},
+		},
+		CurrentContext: "mcp",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to write kubeconfig for the MCP cluster: %w", err)
+	}
+	data[mcpKubeconfigKey] = kubeconfig
+	checksum := sha256.Sum256(kubeconfig)
+	return &MCPKubeconfig{
+		secret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      mcpKubeconfigSecretName,
+				Namespace: namespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		},
+		checksum: hex.EncodeToString(checksum[:]),
+	}, nil
+}
+
+// Secret returns the secret with the kubeconfig and the current token for the MCP cluster.
+// Apply it on every reconcile to keep the token rotated.
+func (k *MCPKubeconfig) Secret() *corev1.Secret {
+	return k.secret.DeepCopy()
+}
+
+// Mount mounts the secret into all containers of the pod template and points their KUBECONFIG environment variable to it.
+// The checksum of the kubeconfig is recorded as annotation, so that the pods are replaced if it changes.
+func (k *MCPKubeconfig) Mount(template *corev1.PodTemplateSpec) {
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[mcpKubeconfigChecksumAnnotation] = k.checksum
+	template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+		Name: mcpKubeconfigSecretName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: k.secret.Name},
+		},
+	})
+	for i := range template.Spec.Containers {
+		c := &template.Spec.Containers[i]
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+			Name:      mcpKubeconfigSecretName,
+			MountPath: mcpKubeconfigMountPath,
+			ReadOnly:  true,
+		})
+		c.Env = append(c.Env, corev1.EnvVar{
+			Name:  "KUBECONFIG",
+			Value: path.Join(mcpKubeconfigMountPath, mcpKubeconfigKey),
+		})
+	}
+}
+
+// opencontrolplane-gen:fi
@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+// opencontrolplane-gen:if WORKLOADCLUSTER=true
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openmcp-project/controller-utils/pkg/clusters"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const testCAData = "-----BEGIN CERTIFICATE-----\ntest\n-----END CERTIFICATE-----\n"
+
+func TestNewMCPKubeconfig(t *testing.T) {
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(caFile, []byte(testCAData), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		cluster   *clusters.Cluster
+		wantErr   bool
+		wantToken bool
+		wantCert  bool
+	}{
+		{name: "no cluster", wantErr: true},
+		{name: "no REST config", cluster: clusters.New("mcp"), wantErr: true},
+		{
+			name:    "no credentials",
+			cluster: clusters.New("mcp").WithRESTConfig(&rest.Config{Host: "https://mcp.example.com"}),
+			wantErr: true,
+		},
+		{
+			name: "token",
+			cluster: clusters.New("mcp").WithRESTConfig(&rest.Config{
+				Host:            "https://mcp.example.com",
+				BearerToken:     "token",
+				TLSClientConfig: rest.TLSClientConfig{CAData: []byte(testCAData)},
+			}),
+			wantToken: true,
+		},
+		{
+			name: "client certificate and CA file",
+			cluster: clusters.New("mcp").WithRESTConfig(&rest.Config{
+				Host:            "https://mcp.example.com",
+				TLSClientConfig: rest.TLSClientConfig{CertData: []byte("cert"), KeyData: []byte("key"), CAFile: caFile},
+			}),
+			wantCert: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := NewMCPKubeconfig(tt.cluster, "workload")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewMCPKubeconfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			secret := k.Secret()
+			if secret.Namespace != "workload" || secret.Name != mcpKubeconfigSecretName {
+				t.Errorf("secret = %s/%s, want workload/%s", secret.Namespace, secret.Name, mcpKubeconfigSecretName)
+			}
+			if _, ok := secret.Data[mcpTokenKey]; ok != tt.wantToken {
+				t.Errorf("secret contains token = %v, want %v", ok, tt.wantToken)
+			}
+			cfg, err := clientcmd.Load(secret.Data[mcpKubeconfigKey])
+			if err != nil {
+				t.Fatalf("unable to load kubeconfig: %v", err)
+			}
+			cluster, authInfo := cfg.Clusters["mcp"], cfg.AuthInfos["mcp"]
+			if cluster == nil || authInfo == nil || cfg.CurrentContext != "mcp" {
+				t.Fatalf("kubeconfig = %+v, want cluster, user and context mcp", cfg)
+			}
+			if cluster.Server != "https://mcp.example.com" {
+				t.Errorf("server = %s, want https://mcp.example.com", cluster.Server)
+			}
+			// the CA bundle is inlined, the CA file does not exist in the pods
+			if string(cluster.CertificateAuthorityData) != testCAData {
+				t.Errorf("certificate authority data = %q, want %q", cluster.CertificateAuthorityData, testCAData)
+			}
+			// the token is only referenced, so that it can be rotated without changing the kubeconfig
+			if authInfo.Token != "" || (authInfo.TokenFile != "") != tt.wantToken {
+				t.Errorf("token = %q, token file = %q, want token file %v", authInfo.Token, authInfo.TokenFile, tt.wantToken)
+			}
+			if (len(authInfo.ClientCertificateData) > 0) != tt.wantCert {
+				t.Errorf("client certificate = %q, want %v", authInfo.ClientCertificateData, tt.wantCert)
+			}
+		})
+	}
+}
+
+func TestMCPKubeconfigChecksum(t *testing.T) {
+	kubeconfig := func(host, token string) *MCPKubeconfig {
+		t.Helper()
+		k, err := NewMCPKubeconfig(clusters.New("mcp").WithRESTConfig(&rest.Config{Host: host, BearerToken: token}), "workload")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return k
+	}
+	original := kubeconfig("https://mcp.example.com", "token")
+	if rotated := kubeconfig("https://mcp.example.com", "rotated"); rotated.checksum != original.checksum {
+		t.Errorf("checksum changed with the token, pods would be restarted on every token rotation")
+	}
+	if moved := kubeconfig("https://other.example.com", "token"); moved.checksum == original.checksum {
+		t.Errorf("checksum did not change with the endpoint")
+	}
+}
+
+func TestMCPKubeconfigMount(t *testing.T) {
+	k, err := NewMCPKubeconfig(clusters.New("mcp").WithRESTConfig(&rest.Config{Host: "https://mcp.example.com", BearerToken: "token"}), "workload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "manager"}, {Name: "sidecar"}}},
+	}
+	k.Mount(template)
+
+	if got := template.Annotations[mcpKubeconfigChecksumAnnotation]; got != k.checksum {
+		t.Errorf("checksum annotation = %q, want %q", got, k.checksum)
+	}
+	if len(template.Spec.Volumes) != 1 || template.Spec.Volumes[0].Secret == nil || template.Spec.Volumes[0].Secret.SecretName != mcpKubeconfigSecretName {
+		t.Fatalf("volumes = %+v, want the kubeconfig secret", template.Spec.Volumes)
+	}
+	for _, c := range template.Spec.Containers {
+		if len(c.VolumeMounts) != 1 || c.VolumeMounts[0].MountPath != mcpKubeconfigMountPath || !c.VolumeMounts[0].ReadOnly {
+			t.Errorf("container %s volume mounts = %+v, want read-only mount at %s", c.Name, c.VolumeMounts, mcpKubeconfigMountPath)
+		}
+		if len(c.Env) != 1 || c.Env[0].Name != "KUBECONFIG" || !strings.HasPrefix(c.Env[0].Value, mcpKubeconfigMountPath) {
+			t.Errorf("container %s env = %+v, want KUBECONFIG in %s", c.Name, c.Env, mcpKubeconfigMountPath)
+		}
+	}
+}
+
+// opencontrolplane-gen:fi
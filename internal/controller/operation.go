@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package controller
+
+import (
+	"context"
+	"time"
+
+	ctrlutils "github.com/openmcp-project/controller-utils/pkg/controller"
+	openmcpconst "github.com/openmcp-project/openmcp-operator/api/constants"
+	meta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// pausedRequeueInterval is used to revisit objects in deletion that are paused by the ignore operation annotation.
+const pausedRequeueInterval = time.Minute
+
+// isIgnored returns true if the object carries the ignore operation annotation.
+func isIgnored(obj client.Object) bool {
+	return ctrlutils.HasAnnotationWithValue(obj, openmcpconst.OperationAnnotation, openmcpconst.OperationAnnotationValueIgnore)
+}
+
+// OperationAnnotationChanged returns a predicate for updates that add the reconcile operation annotation
+// or add or remove the ignore operation annotation. These updates only change the metadata of the object,
+// so they do not pass predicates that filter for changes of the generation.
+// Removing the reconcile annotation after a reconcile does not pass the predicate.
+func OperationAnnotationChanged() predicate.Predicate {
+	return predicate.And(
+		ctrlutils.OnUpdatePredicate(),
+		predicate.Or(
+			ctrlutils.GotAnnotationPredicate(openmcpconst.OperationAnnotation, openmcpconst.OperationAnnotationValueReconcile),
+			ctrlutils.GotAnnotationPredicate(openmcpconst.OperationAnnotation, openmcpconst.OperationAnnotationValueIgnore),
+			ctrlutils.LostAnnotationPredicate(openmcpconst.OperationAnnotation, openmcpconst.OperationAnnotationValueIgnore),
+		),
+	)
+}
+
+// setPaused marks the object as paused because of the ignore operation annotation.
+func setPaused(obj conditionObject) {
+	setCondition(obj, apiv1alpha1.ConditionTypePaused, metav1.ConditionTrue, apiv1alpha1.ReasonOperationIgnore,
+		"reconciliation is paused by the "+openmcpconst.OperationAnnotation+" annotation")
+}
+
+// clearPaused removes the paused condition once the ignore operation annotation is gone.
+func clearPaused(obj conditionObject) {
+	meta.RemoveStatusCondition(obj.GetConditions(), apiv1alpha1.ConditionTypePaused)
+}
+
+// removeReconcileAnnotation strips the reconcile operation annotation from the object after it has been reconciled.
+// The patch is sent for a copy of the object so that in-memory status changes are not overwritten by the response.
+func removeReconcileAnnotation(ctx context.Context, c client.Client, obj client.Object) error {
+	if !ctrlutils.HasAnnotationWithValue(obj, openmcpconst.OperationAnnotation, openmcpconst.OperationAnnotationValueReconcile) {
+		return nil
+	}
+	patched, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return nil
+	}
+	annotations := patched.GetAnnotations()
+	delete(annotations, openmcpconst.OperationAnnotation)
+	patched.SetAnnotations(annotations)
+	if err := c.Patch(ctx, patched, client.MergeFrom(obj)); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	obj.SetAnnotations(patched.GetAnnotations())
+	obj.SetResourceVersion(patched.GetResourceVersion())
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	openmcpconst "github.com/openmcp-project/openmcp-operator/api/constants"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+func TestOperationAnnotationChanged(t *testing.T) {
+	// opencontrolplane-gen:replace Foo=KIND
+	obj := func(generation int64, annotations map[string]string) *apiv1alpha1.Foo {
+		// opencontrolplane-gen:replace Foo=KIND
+		return &apiv1alpha1.Foo{ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: generation, Annotations: annotations}}
+	}
+	reconcile := map[string]string{openmcpconst.OperationAnnotation: openmcpconst.OperationAnnotationValueReconcile}
+	ignore := map[string]string{openmcpconst.OperationAnnotation: openmcpconst.OperationAnnotationValueIgnore}
+	other := map[string]string{"example.com/other": "value"}
+
+	tests := []struct {
+		name     string
+		old, new map[string]string
+		want     bool
+	}{
+		{name: "reconcile annotation added", old: nil, new: reconcile, want: true},
+		{name: "ignore annotation added", old: nil, new: ignore, want: true},
+		{name: "ignore annotation removed", old: ignore, new: nil, want: true},
+		{name: "ignore annotation replaced by reconcile", old: ignore, new: reconcile, want: true},
+		{name: "reconcile annotation removed after reconcile", old: reconcile, new: nil, want: false},
+		{name: "other annotation added", old: nil, new: other, want: false},
+		{name: "annotations unchanged", old: reconcile, new: reconcile, want: false},
+	}
+	p := OperationAnnotationChanged()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the generation is unchanged, annotation updates do not increase it
+			got := p.Update(event.UpdateEvent{ObjectOld: obj(1, tt.old), ObjectNew: obj(1, tt.new)})
+			if got != tt.want {
+				t.Errorf("Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if p.Create(event.CreateEvent{Object: obj(1, reconcile)}) {
+		t.Errorf("Create() = true, create events are handled by the service object controller")
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	clustersv1alpha1 "github.com/openmcp-project/openmcp-operator/api/clusters/v1alpha1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	meta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+var (
+	// readVerbs are required to read objects and to watch them for changes.
+	readVerbs = []string{"get", "list", "watch"}
+	// namedObjectVerbs are required to update and delete an object that is known by name.
+	namedObjectVerbs = []string{"get", "update", "patch", "delete"}
+	// cleanupVerbs are required to delete objects and to remove their finalizers.
+	cleanupVerbs = []string{"patch", "delete"}
+	// updateVerbs are required to update existing objects, e.g. their finalizers or their status.
+	updateVerbs = []string{"update", "patch"}
+)
+
+// PermissionManifest declares the RBAC rules the service provider requires on a cluster.
+// Rules are derived from the objects the reconciler manages and can be extended with additional rules.
+// The manifest is translated into the token configuration of the corresponding cluster access request.
+type PermissionManifest struct {
+	scheme *runtime.Scheme
+	rules  []rbacv1.PolicyRule
+	// namespacedRules are granted by a Role in the namespace instead of a ClusterRole.
+	namespacedRules map[string][]rbacv1.PolicyRule
+	errs            []error
+}
+
+// NewPermissionManifest returns an empty PermissionManifest.
+// The scheme is used to determine the API group and resource of managed objects.
+func NewPermissionManifest(scheme *runtime.Scheme) *PermissionManifest {
+	return &PermissionManifest{
+		scheme: scheme,
+	}
+}
+
+// WithRules adds the given rules to the manifest.
+func (m *PermissionManifest) WithRules(rules ...rbacv1.PolicyRule) *PermissionManifest {
+	m.rules = append(m.rules, rules...)
+	return m
+}
+
+// WithNamespacedRules adds the given rules to the manifest, they are only granted in the given namespace.
+func (m *PermissionManifest) WithNamespacedRules(namespace string, rules ...rbacv1.PolicyRule) *PermissionManifest {
+	if m.namespacedRules == nil {
+		m.namespacedRules = map[string][]rbacv1.PolicyRule{}
+	}
+	m.namespacedRules[namespace] = append(m.namespacedRules[namespace], rules...)
+	return m
+}
+
+// ManagesObjects adds the rules required to create the given objects and to update or delete them by name.
+func (m *PermissionManifest) ManagesObjects(objs ...client.Object) *PermissionManifest {
+	for _, obj := range objs {
+		gvk, err := apiutil.GVKForObject(obj, m.scheme)
+		if err != nil {
+			m.errs = append(m.errs, fmt.Errorf("unable to determine kind of managed object %s: %w", obj.GetName(), err))
+			continue
+		}
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		m.rules = append(m.rules,
+			rbacv1.PolicyRule{
+				APIGroups: []string{gvr.Group},
+				Resources: []string{gvr.Resource},
+				Verbs:     []string{"create"},
+			},
+			rbacv1.PolicyRule{
+				APIGroups:     []string{gvr.Group},
+				Resources:     []string{gvr.Resource},
+				ResourceNames: []string{obj.GetName()},
+				Verbs:         namedObjectVerbs,
+			},
+		)
+	}
+	return m
+}
+
+// ReadsCustomResources adds the rules required to read all objects of the resources defined by the given CRDs.
+func (m *PermissionManifest) ReadsCustomResources(crds ...*apiextensionsv1.CustomResourceDefinition) *PermissionManifest {
+	for _, crd := range crds {
+		m.rules = append(m.rules, rbacv1.PolicyRule{
+			APIGroups: []string{crd.Spec.Group},
+			Resources: []string{crd.Spec.Names.Plural},
+			Verbs:     readVerbs,
+		})
+	}
+	return m
+}
+
+// DeletesCustomResources adds the rules required to delete all objects of the resources defined by the given CRDs
+// and to remove their finalizers.
+func (m *PermissionManifest) DeletesCustomResources(crds ...*apiextensionsv1.CustomResourceDefinition) *PermissionManifest {
+	for _, crd := range crds {
+		m.rules = append(m.rules, rbacv1.PolicyRule{
+			APIGroups: []string{crd.Spec.Group},
+			Resources: []string{crd.Spec.Names.Plural},
+			Verbs:     cleanupVerbs,
+		})
+	}
+	return m
+}
+
+// ReconcilesCustomResources adds the rules required to reconcile all objects of the resources defined by the given CRDs,
+// that is to read and update them and to update their status.
+func (m *PermissionManifest) ReconcilesCustomResources(crds ...*apiextensionsv1.CustomResourceDefinition) *PermissionManifest {
+	m.ReadsCustomResources(crds...)
+	for _, crd := range crds {
+		m.rules = append(m.rules, rbacv1.PolicyRule{
+			APIGroups: []string{crd.Spec.Group},
+			Resources: []string{crd.Spec.Names.Plural, crd.Spec.Names.Plural + "/status"},
+			Verbs:     updateVerbs,
+		})
+	}
+	return m
+}
+
+// Rules returns the deduplicated cluster-wide rules of the manifest.
+func (m *PermissionManifest) Rules() ([]rbacv1.PolicyRule, error) {
+	if err := errors.Join(m.errs...); err != nil {
+		return nil, err
+	}
+	return dedupeRules(m.rules), nil
+}
+
+// TokenConfig translates the manifest into the token configuration of a cluster access request.
+// The cluster-wide rules are requested as ClusterRole, the namespaced rules as Role per namespace.
+func (m *PermissionManifest) TokenConfig() (*clustersv1alpha1.TokenConfig, error) {
+	rules, err := m.Rules()
+	if err != nil {
+		return nil, err
+	}
+	cfg := &clustersv1alpha1.TokenConfig{}
+	if len(rules) > 0 {
+		cfg.Permissions = []clustersv1alpha1.PermissionsRequest{
+			{
+				Rules: rules,
+			},
+		}
+	}
+	for _, namespace := range slices.Sorted(maps.Keys(m.namespacedRules)) {
+		cfg.Permissions = append(cfg.Permissions, clustersv1alpha1.PermissionsRequest{
+			Namespace: namespace,
+			Rules:     dedupeRules(m.namespacedRules[namespace]),
+		})
+	}
+	return cfg, nil
+}
+
+// dedupeRules returns the rules without duplicates in the order of their first occurrence.
+func dedupeRules(rules []rbacv1.PolicyRule) []rbacv1.PolicyRule {
+	result := make([]rbacv1.PolicyRule, 0, len(rules))
+	for _, rule := range rules {
+		if !slices.ContainsFunc(result, func(r rbacv1.PolicyRule) bool { return equalRules(r, rule) }) {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+func equalRules(a, b rbacv1.PolicyRule) bool {
+	return slices.Equal(a.APIGroups, b.APIGroups) &&
+		slices.Equal(a.Resources, b.Resources) &&
+		slices.Equal(a.ResourceNames, b.ResourceNames) &&
+		slices.Equal(a.NonResourceURLs, b.NonResourceURLs) &&
+		slices.Equal(a.Verbs, b.Verbs)
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	clustersv1alpha1 "github.com/openmcp-project/openmcp-operator/api/clusters/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestPermissionManifestRules(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	leases := rbacv1.PolicyRule{APIGroups: []string{"coordination.k8s.io"}, Resources: []string{"leases"}, Verbs: []string{"get"}}
+	configMapCreate := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"create"}}
+	configMap := func(name string) rbacv1.PolicyRule {
+		return rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{name}, Verbs: namedObjectVerbs}
+	}
+	cm := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+		Group: "example.com",
+		Names: apiextensionsv1.CustomResourceDefinitionNames{Plural: "widgets"},
+	}}
+
+	tests := []struct {
+		name     string
+		manifest *PermissionManifest
+		want     []rbacv1.PolicyRule
+		wantErr  bool
+	}{
+		{
+			name:     "empty",
+			manifest: NewPermissionManifest(scheme),
+			want:     []rbacv1.PolicyRule{},
+		},
+		{
+			name:     "identical rules are deduplicated",
+			manifest: NewPermissionManifest(scheme).WithRules(leases, leases),
+			want:     []rbacv1.PolicyRule{leases},
+		},
+		{
+			name:     "create rule of managed objects of the same kind is shared",
+			manifest: NewPermissionManifest(scheme).ManagesObjects(cm("a"), cm("b"), cm("a")),
+			want:     []rbacv1.PolicyRule{configMapCreate, configMap("a"), configMap("b")},
+		},
+		{
+			name:     "order of first occurrence is kept",
+			manifest: NewPermissionManifest(scheme).WithRules(leases).ManagesObjects(cm("a")).WithRules(leases),
+			want:     []rbacv1.PolicyRule{leases, configMapCreate, configMap("a")},
+		},
+		{
+			name:     "rules differing in verbs are kept",
+			manifest: NewPermissionManifest(scheme).WithRules(leases, rbacv1.PolicyRule{APIGroups: leases.APIGroups, Resources: leases.Resources, Verbs: []string{"list"}}),
+			want:     []rbacv1.PolicyRule{leases, {APIGroups: leases.APIGroups, Resources: leases.Resources, Verbs: []string{"list"}}},
+		},
+		{
+			name:     "namespaced rules are not cluster-wide",
+			manifest: NewPermissionManifest(scheme).WithNamespacedRules("ns", leases),
+			want:     []rbacv1.PolicyRule{},
+		},
+		{
+			name:     "reconciled custom resources can be read and updated with their status",
+			manifest: NewPermissionManifest(scheme).ReconcilesCustomResources(crd),
+			want: []rbacv1.PolicyRule{
+				{APIGroups: []string{"example.com"}, Resources: []string{"widgets"}, Verbs: readVerbs},
+				{APIGroups: []string{"example.com"}, Resources: []string{"widgets", "widgets/status"}, Verbs: updateVerbs},
+			},
+		},
+		{
+			name:     "unknown kind fails",
+			manifest: NewPermissionManifest(scheme).ManagesObjects(&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "a"}}),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.manifest.Rules()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Rules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !equality.Semantic.DeepEqual(got, tt.want) {
+				t.Errorf("Rules() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPermissionManifestTokenConfig(t *testing.T) {
+	leases := rbacv1.PolicyRule{APIGroups: []string{"coordination.k8s.io"}, Resources: []string{"leases"}, Verbs: []string{"get"}}
+	events := rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"events"}, Verbs: []string{"create"}}
+
+	tests := []struct {
+		name     string
+		manifest *PermissionManifest
+		want     []clustersv1alpha1.PermissionsRequest
+	}{
+		{
+			name:     "empty",
+			manifest: NewPermissionManifest(nil),
+			want:     nil,
+		},
+		{
+			name:     "cluster-wide rules",
+			manifest: NewPermissionManifest(nil).WithRules(leases, leases),
+			want:     []clustersv1alpha1.PermissionsRequest{{Rules: []rbacv1.PolicyRule{leases}}},
+		},
+		{
+			name: "namespaced rules are requested per namespace in order",
+			manifest: NewPermissionManifest(nil).
+				WithNamespacedRules("b", events).
+				WithNamespacedRules("a", leases, leases).
+				WithNamespacedRules("b", leases),
+			want: []clustersv1alpha1.PermissionsRequest{
+				{Namespace: "a", Rules: []rbacv1.PolicyRule{leases}},
+				{Namespace: "b", Rules: []rbacv1.PolicyRule{events, leases}},
+			},
+		},
+		{
+			name:     "cluster-wide and namespaced rules",
+			manifest: NewPermissionManifest(nil).WithRules(events).WithNamespacedRules("a", leases),
+			want: []clustersv1alpha1.PermissionsRequest{
+				{Rules: []rbacv1.PolicyRule{events}},
+				{Namespace: "a", Rules: []rbacv1.PolicyRule{leases}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := tt.manifest.TokenConfig()
+			if err != nil {
+				t.Fatalf("TokenConfig() error = %v", err)
+			}
+			if !equality.Semantic.DeepEqual(cfg.Permissions, tt.want) {
+				t.Errorf("TokenConfig().Permissions = %+v, want %+v", cfg.Permissions, tt.want)
+			}
+		})
+	}
+}
@@ -19,36 +19,50 @@ package controller
 
 import (
 	"context"
-
-	// opencontrolplane-gen:if SAMPLECODE=true
-	"fmt"
 	"time"
 
-	// opencontrolplane-gen:fi
 	// opencontrolplane-gen:if SECRETWATCHER=true
 	corev1 "k8s.io/api/core/v1"
 	// opencontrolplane-gen:fi
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	// opencontrolplane-gen:if WORKLOADCLUSTER=true
+	"k8s.io/apimachinery/pkg/types"
+	// opencontrolplane-gen:fi
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	// opencontrolplane-gen:if SAMPLECODE=true
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	meta "k8s.io/apimachinery/pkg/api/meta"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	logf "sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	// opencontrolplane-gen:fi
+
 	"github.com/openmcp-project/controller-utils/pkg/clusters"
-	// opencontrolplane-gen:if SAMPLECODE=true
-	"github.com/openmcp-project/opencontrolplane-runtime/pkg/serviceprovider"
-	// opencontrolplane-gen:fi
 	clusteraccess "github.com/openmcp-project/opencontrolplane-runtime/pkg/serviceprovider/clusteraccess"
 
 	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
 	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+	// opencontrolplane-gen:if SAMPLECODE=true
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/domainservice"
+	// opencontrolplane-gen:fi
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/health"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/metrics"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/tracing"
+)
+
+const (
+	// accessRequeueInterval is used to revisit objects while access to the MCP cluster has not been granted yet.
+	accessRequeueInterval = 10 * time.Second
+	// deletionRequeueInterval is used to revisit objects while their deletion is blocked or in progress.
+	deletionRequeueInterval = 10 * time.Second
 )
 
 // opencontrolplane-gen:replace Foo=KIND
@@ -62,90 +76,279 @@ type FooReconciler struct {
 	PlatformCluster *clusters.Cluster
 	// PodNamespace is the namespace where this controller is deployed in.
 	PodNamespace string
+	// opencontrolplane-gen:replace Foo=KIND
+	// Recorder records events on the Foo resources.
+	Recorder events.EventRecorder
+	// FieldManager is the field manager used to apply managed objects with server-side apply.
+	FieldManager string
+	// Watchdog tracks running reconciles for the liveness probe, it is optional.
+	Watchdog *health.Watchdog
+	// opencontrolplane-gen:if WORKLOADCLUSTER=true
+	// ServiceControllerAccess returns the access to the MCP cluster that is granted to the domain service controller of a service object.
+	// Its credentials are handed to the domain service controller in the workload cluster.
+	ServiceControllerAccess ClusterAccessFunc
+	// opencontrolplane-gen:fi
 }
 
-// CreateOrUpdate is called on every add or update event
+// CreateOrUpdate is called on every add or update event.
+// Objects annotated with openmcp.cloud/operation=ignore are skipped and reported as Paused,
+// the openmcp.cloud/operation=reconcile annotation is removed after a successful reconcile.
 // opencontrolplane-gen:replace Foo=KIND
-func (r *FooReconciler) CreateOrUpdate(ctx context.Context, svcobj *apiv1alpha1.Foo, _ *apiv1alpha1.ProviderConfig, clusters clusteraccess.ClusterContext) (ctrl.Result, error) {
+func (r *FooReconciler) CreateOrUpdate(ctx context.Context, svcobj *apiv1alpha1.Foo, pc *apiv1alpha1.ProviderConfig, clusters clusteraccess.ClusterContext) (res ctrl.Result, err error) {
+	// opencontrolplane-gen:replace Foo=KIND
+	ctx, span := tracing.StartObject(ctx, "Foo.CreateOrUpdate", svcobj)
+	defer func() { tracing.End(span, err) }()
+	defer r.Watchdog.Track()()
+	defer metrics.ObserveReconcile(metrics.OperationCreateOrUpdate, svcobj.Namespace, svcobj.Name)()
+	if isIgnored(svcobj) {
+		setPaused(svcobj)
+		return ctrl.Result{}, nil
+	}
+	clearPaused(svcobj)
+	if !r.mcpAccessGranted(svcobj, clusters) {
+		return ctrl.Result{RequeueAfter: accessRequeueInterval}, nil
+	}
+	l := logf.FromContext(ctx)
+	inv := &inventory{}
 	// opencontrolplane-gen:if SAMPLECODE=true
+	statusProgressing(svcobj, apiv1alpha1.ReasonReconciling, "reconcile in progress")
+	target, err := targetVersion(svcobj, pc)
+	if err != nil {
+		// the version is only changed by an update of the spec or the ProviderConfig, which triggers a new reconcile
+		setCondition(svcobj, apiv1alpha1.ConditionTypeManagedResourcesApplied, metav1.ConditionFalse, versionReason(err), err.Error())
+		statusDegraded(svcobj, versionReason(err), err)
+		return pollResult(pc), nil
+	}
+	startRollout(svcobj, target)
+	managedObjs, err := domainservice.CRDs(target)
+	if err != nil {
+		statusDegraded(svcobj, apiv1alpha1.ReasonApplyFailed, err)
+		return ctrl.Result{}, err
+	}
+	if err := r.applyManagedCRDs(ctx, svcobj, pc, clusters.MCPCluster.Client(), managedObjs, inv); err != nil {
+		if apierrors.IsConflict(err) {
+			// fields are owned by another field manager, retrying does not help until the conflict is resolved
+			return pollResult(pc), nil
+		}
+		return ctrl.Result{}, err
+	}
+	setCondition(svcobj, apiv1alpha1.ConditionTypeManagedResourcesApplied, metav1.ConditionTrue, apiv1alpha1.ReasonApplied, "managed resources have been applied to the MCP cluster")
+	rolledOut := completeRollout(svcobj, target, managedObjs)
+	// opencontrolplane-gen:fi
+	// opencontrolplane-gen:if SAMPLECODE=false
+	// TODO: apply the managed objects and add them to the inventory
+	// opencontrolplane-gen:fi
+	// opencontrolplane-gen:if WORKLOADCLUSTER=true
+	if err := r.deployServiceController(ctx, svcobj, pc, clusters, inv); err != nil {
+		l.Error(err, "deploy service controller failed")
+		statusDegraded(svcobj, applyFailedReason(err), err)
+		return ctrl.Result{}, err
+	}
+	// opencontrolplane-gen:fi
+	// objects that are no longer part of the inventory are not produced by the current spec anymore
+	inUse, err := pruneInventory(ctx, svcobj.Status.Inventory, inv.entries, inventoryClients(clusters))
+	if err != nil {
+		l.Error(err, "prune managed objects failed")
+		statusDegraded(svcobj, apiv1alpha1.ReasonPruneFailed, err)
+		return ctrl.Result{}, err
+	}
+	svcobj.Status.Inventory = append(inv.entries, inUse...)
+	if len(inUse) > 0 {
+		// CRDs dropped by the domain service version are pruned once their instances have been removed
+		setCondition(svcobj, apiv1alpha1.ConditionTypeProgressing, metav1.ConditionTrue, apiv1alpha1.ReasonCRDsInUse, inUseMessage(inUse))
+		return ctrl.Result{RequeueAfter: deletionRequeueInterval}, nil
+	}
+	// opencontrolplane-gen:if SAMPLECODE=true
+	if !rolledOut {
+		return ctrl.Result{RequeueAfter: rolloutRequeueInterval}, nil
+	}
+	statusReady(svcobj)
+	// opencontrolplane-gen:fi
+	return pollResult(pc), removeReconcileAnnotation(ctx, r.OnboardingCluster.Client(), svcobj)
+}
+
+// Delete is called on every delete event.
+// Objects annotated with openmcp.cloud/operation=ignore keep their finalizer and are reported as Paused.
+// The deletion policy of the object decides whether the managed resources are deleted, orphaned or
+// deleted together with the remaining user resources.
+// opencontrolplane-gen:replace Foo=KIND
+func (r *FooReconciler) Delete(ctx context.Context, obj *apiv1alpha1.Foo, _ *apiv1alpha1.ProviderConfig, clusters clusteraccess.ClusterContext) (res ctrl.Result, err error) {
+	// opencontrolplane-gen:replace Foo=KIND
+	ctx, span := tracing.StartObject(ctx, "Foo.Delete", obj)
+	defer func() { tracing.End(span, err) }()
+	defer r.Watchdog.Track()()
+	// runs after the duration of this reconcile has been observed
+	defer forgetMetrics(obj, &res, &err)
+	defer metrics.ObserveReconcile(metrics.OperationDelete, obj.Namespace, obj.Name)()
+	if isIgnored(obj) {
+		// keep the finalizer in place until the ignore annotation is removed
+		setPaused(obj)
+		return ctrl.Result{RequeueAfter: pausedRequeueInterval}, nil
+	}
+	clearPaused(obj)
+	statusTerminating(obj)
+	obj.Status.DeletionPolicy = obj.GetDeletionPolicy()
+	if obj.Status.DeletionPolicy == apiv1alpha1.DeletionPolicyOrphan {
+		return r.orphan(ctx, obj, clusters)
+	}
+	if !mcpAccessReady(obj, clusters) {
+		return ctrl.Result{RequeueAfter: accessRequeueInterval}, nil
+	}
 	l := logf.FromContext(ctx)
-	serviceprovider.StatusProgressing(svcobj, "Reconciling", "Reconcile in progress")
-	managedObj := &apiextensionsv1.CustomResourceDefinition{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "foos.example.domain",
-		},
-	}
-	if _, err := ctrl.CreateOrUpdate(ctx, clusters.MCPCluster.Client(), managedObj, func() error {
-		managedObj.Spec = fooCRD().Spec
-		return nil
-	}); err != nil {
-		l.Error(err, "createOrUpdate failed")
+	// opencontrolplane-gen:if SAMPLECODE=true
+	managedObjs, err := domainservice.AllCRDs()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// Check if no custom resource objects related to the managed domain service CRDs remain on a ControlPlane before deleting the service provider
+	fooItems, kinds, err := listUserResources(ctx, clusters.MCPCluster.Client(), managedObjs)
+	if err != nil {
+		l.Error(err, "list user resources failed")
+		statusDegraded(obj, apiv1alpha1.ReasonListFailed, err)
 		return ctrl.Result{}, err
 	}
-	serviceprovider.StatusReady(svcobj)
+	if len(fooItems) != 0 {
+		blocking := blockingResources(fooItems)
+		blockingChanged := !equality.Semantic.DeepEqual(obj.Status.BlockingResources, blocking)
+		obj.Status.BlockingResources = blocking
+		msg := blockingMessage(kinds, len(fooItems), blocking)
+		if obj.Status.DeletionPolicy == apiv1alpha1.DeletionPolicyForceDelete {
+			if err := forceDeleteUserResources(ctx, clusters.MCPCluster.Client(), obj, fooItems); err != nil {
+				l.Error(err, "delete user resources failed")
+				statusDegraded(obj, apiv1alpha1.ReasonDeleteFailed, err)
+				return ctrl.Result{}, err
+			}
+			setCondition(obj, apiv1alpha1.ConditionTypeDeletionBlocked, metav1.ConditionTrue, apiv1alpha1.ReasonForceDeleting,
+				"deleting remaining user resources, "+msg)
+			return ctrl.Result{RequeueAfter: deletionRequeueInterval}, nil
+		}
+		setCondition(obj, apiv1alpha1.ConditionTypeDeletionBlocked, metav1.ConditionTrue, apiv1alpha1.ReasonUserResourcesPresent,
+			"user resources still present, "+msg)
+		if blockingChanged {
+			r.warningEvent(obj, nil, eventReasonDeletionBlocked, eventActionDelete, "deletion blocked by user resources in the MCP cluster, %s", msg)
+		}
+		return ctrl.Result{RequeueAfter: deletionRequeueInterval}, nil
+	}
+	setCondition(obj, apiv1alpha1.ConditionTypeDeletionBlocked, metav1.ConditionFalse, apiv1alpha1.ReasonNoUserResources, "no user resources present")
+	obj.Status.BlockingResources = nil
+	if len(obj.Status.Inventory) == 0 {
+		// the managed objects may have been applied before the inventory was recorded
+		inv := &inventory{}
+		for _, managedObj := range managedObjs {
+			if err := inv.add(clusters.MCPCluster.Client(), apiv1alpha1.InventoryClusterMCP, managedObj); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		obj.Status.Inventory = inv.entries
+	}
 	// opencontrolplane-gen:fi
 	// opencontrolplane-gen:if SAMPLECODE=false
-	// TODO
-	_, _, _ = ctx, svcobj, clusters
+	// TODO: block the deletion while user resources remain on the MCP cluster
 	// opencontrolplane-gen:fi
+	remaining, err := deleteInventory(ctx, obj.Status.Inventory, inventoryClients(clusters))
+	obj.Status.Inventory = remaining
+	if err != nil {
+		l.Error(err, "delete managed objects failed")
+		statusDegraded(obj, apiv1alpha1.ReasonDeleteFailed, err)
+		return ctrl.Result{}, err
+	}
+	if len(remaining) > 0 {
+		// managed objects are still being deleted
+		return ctrl.Result{RequeueAfter: deletionRequeueInterval}, nil
+	}
+	r.normalEvent(obj, nil, eventReasonDeletionCompleted, eventActionDelete, "deletion completed, managed resources have been deleted")
 	return ctrl.Result{}, nil
 }
 
-// Delete is called on every delete event
+// forgetMetrics removes the metrics of a service object once its deletion has completed,
+// i.e. Delete neither failed nor requeued and the finalizer is removed.
+func forgetMetrics(obj metav1.Object, res *ctrl.Result, err *error) {
+	if *err == nil && res.IsZero() {
+		metrics.ForgetObject(obj.GetNamespace(), obj.GetName())
+	}
+}
+
 // opencontrolplane-gen:replace Foo=KIND
-func (r *FooReconciler) Delete(ctx context.Context, obj *apiv1alpha1.Foo, _ *apiv1alpha1.ProviderConfig, clusters clusteraccess.ClusterContext) (ctrl.Result, error) {
-	// opencontrolplane-gen:if SAMPLECODE=true
-	l := logf.FromContext(ctx)
-	serviceprovider.StatusTerminating(obj)
-	managedObj := fooCRD()
-	// Check if no custom resource objects related to the managed domain service CRD remain on a ControlPlane before deleting the service provider
-	fooList := &unstructured.UnstructuredList{}
-	fooList.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   managedObj.Spec.Group,
-		Version: managedObj.Spec.Versions[0].Name,
-		Kind:    managedObj.Spec.Names.ListKind,
-	})
-	if err := clusters.MCPCluster.Client().List(ctx, fooList); err != nil {
-		if !meta.IsNoMatchError(err) {
-			l.Error(err, "list Foo resources failed")
-			return ctrl.Result{}, err
+// orphan handles the deletion of a Foo with the Orphan deletion policy. Managed resources and user resources remain
+// in the MCP cluster, the objects in the workload cluster are deleted as they hold credentials for the MCP cluster.
+// opencontrolplane-gen:replace Foo=KIND
+func (r *FooReconciler) orphan(ctx context.Context, obj *apiv1alpha1.Foo, clusters clusteraccess.ClusterContext) (ctrl.Result, error) {
+	var orphaned, workload []apiv1alpha1.InventoryEntry
+	for _, entry := range obj.Status.Inventory {
+		if entry.Cluster == apiv1alpha1.InventoryClusterWorkload {
+			workload = append(workload, entry)
+		} else {
+			orphaned = append(orphaned, entry)
 		}
 	}
-	if len(fooList.Items) != 0 {
-		meta.SetStatusCondition(obj.GetConditions(), metav1.Condition{
-			Type:               "DeletionBlocked",
-			Status:             metav1.ConditionTrue,
-			ObservedGeneration: obj.GetGeneration(),
-			Reason:             "UserResourcesPresent",
-			Message:            fmt.Sprintf("user resources still present, kind %s: %d", managedObj.Spec.Names.Kind, len(fooList.Items)),
-		})
-		obj.SetObservedGeneration(obj.GetGeneration())
-		obj.SetPhase("Terminating")
-
-		return ctrl.Result{
-			RequeueAfter: time.Second * 10,
-		}, nil
-	}
-	if err := clusters.MCPCluster.Client().Delete(ctx, managedObj); client.IgnoreNotFound(err) != nil {
-		l.Error(err, "delete object failed")
+	remaining, err := deleteInventory(ctx, workload, inventoryClients(clusters))
+	obj.Status.Inventory = append(orphaned, remaining...)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "delete workload objects failed")
+		statusDegraded(obj, apiv1alpha1.ReasonDeleteFailed, err)
 		return ctrl.Result{}, err
 	}
-	if err := clusters.MCPCluster.Client().Get(ctx, client.ObjectKeyFromObject(managedObj), managedObj); err != nil {
-		return reconcile.Result{}, client.IgnoreNotFound(err)
+	if len(remaining) > 0 {
+		// workload objects are still being deleted
+		return ctrl.Result{RequeueAfter: deletionRequeueInterval}, nil
+	}
+	setCondition(obj, apiv1alpha1.ConditionTypeDeletionBlocked, metav1.ConditionFalse, apiv1alpha1.ReasonOrphaned, "managed resources are orphaned")
+	r.normalEvent(obj, nil, eventReasonDeletionCompleted, eventActionDelete, "deletion completed, managed resources are orphaned")
+	return ctrl.Result{}, nil
+}
+
+// mcpAccessReady reports whether access to the MCP cluster has been granted.
+func mcpAccessReady(obj statusObject, clusters clusteraccess.ClusterContext) bool {
+	if clusters.MCPCluster == nil || !clusters.MCPCluster.HasClient() {
+		setCondition(obj, apiv1alpha1.ConditionTypeMCPAccessReady, metav1.ConditionFalse, apiv1alpha1.ReasonWaitingForAccess, "waiting for access to the MCP cluster")
+		return false
+	}
+	setCondition(obj, apiv1alpha1.ConditionTypeMCPAccessReady, metav1.ConditionTrue, apiv1alpha1.ReasonAccessGranted, "access to the MCP cluster has been granted")
+	return true
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// MCPPermissions returns the permissions FooReconciler requires on the MCP cluster.
+// Extend the manifest when the reconciler starts managing additional objects.
+func MCPPermissions(scheme *runtime.Scheme) *PermissionManifest {
+	m := NewPermissionManifest(scheme)
+	// opencontrolplane-gen:if SAMPLECODE=true
+	// every version of the domain service may be installed, so the permissions cover the CRDs of all versions
+	managedCRDs, err := domainservice.AllCRDs()
+	if err != nil {
+		m.errs = append(m.errs, err)
+	}
+	for _, managedCRD := range managedCRDs {
+		m.ManagesObjects(managedCRD).
+			// required to check for remaining user resources before deletion
+			ReadsCustomResources(managedCRD).
+			// required to delete remaining user resources with the ForceDelete deletion policy
+			DeletesCustomResources(managedCRD)
 	}
-	// object still exists
 	// opencontrolplane-gen:fi
 	// opencontrolplane-gen:if SAMPLECODE=false
-	// TODO
-	_, _, _ = ctx, obj, clusters
+	// TODO: declare the objects managed on the MCP cluster, e.g.
+	// m.ManagesObjects(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "my-namespace"}})
 	// opencontrolplane-gen:fi
-	return ctrl.Result{
-		// opencontrolplane-gen:if SAMPLECODE=true
-		RequeueAfter: time.Second * 10,
-		// opencontrolplane-gen:fi
-	}, nil
+	return m
+}
+
+// opencontrolplane-gen:if WORKLOADCLUSTER=true
+// opencontrolplane-gen:replace Foo=KIND
+// WorkloadPermissions returns the permissions FooReconciler requires on the workload cluster for the service object with the given key.
+// The permissions are restricted to the namespace of the service object in the workload cluster.
+// Extend the manifest when the reconciler starts managing objects on the workload cluster.
+func WorkloadPermissions(scheme *runtime.Scheme, key types.NamespacedName) *PermissionManifest {
+	// opencontrolplane-gen:replace Foo=KIND
+	namespace := workloadNamespace(&apiv1alpha1.Foo{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}})
+	return NewPermissionManifest(scheme).
+		WithRules(serviceControllerNamespaceRule(namespace)).
+		WithNamespacedRules(namespace, serviceControllerObjectRules...).
+		// the permissions granted to the domain service controller must be held to create its role
+		WithNamespacedRules(namespace, serviceControllerRules...)
 }
 
+// opencontrolplane-gen:fi
 // opencontrolplane-gen:if SECRETWATCHER=true
 // IsReferencedSecret returns true if the given secret should trigger
 // reconciliation. See serviceprovider.SecretWatcher for details.
@@ -153,56 +356,73 @@ func (r *FooReconciler) Delete(ctx context.Context, obj *apiv1alpha1.Foo, _ *api
 // revive:disable:unused-parameter
 // opencontrolplane-gen:replace Foo=KIND
 func (r *FooReconciler) IsReferencedSecret(ctx context.Context, secret *corev1.Secret, pc *apiv1alpha1.ProviderConfig) bool {
-	if pc == nil {
+	if pc == nil || secret.Namespace != r.PodNamespace {
 		return false
 	}
-	// TODO: Check if the secret is referenced in the provider config, for example:
-	// for _, ref := range pc.Spec.ImagePullSecrets {
-	//     if ref.Name == secret.Name {
-	//         return true
-	//     }
-	// }
+	for _, ref := range pc.Spec.ImagePullSecrets {
+		if ref.Name == secret.Name {
+			return true
+		}
+	}
+	// TODO: Check further secrets referenced in the provider config
 	return false
 }
 
 // opencontrolplane-gen:fi
 // opencontrolplane-gen:if SAMPLECODE=true
-func fooCRD() *apiextensionsv1.CustomResourceDefinition {
-	return &apiextensionsv1.CustomResourceDefinition{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "foos.example.domain",
-		},
-		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
-			Group: "example.domain",
-			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
-				{
-					Name:    "v1alpha1",
-					Served:  true,
-					Storage: true,
-					Schema: &apiextensionsv1.CustomResourceValidation{
-						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
-							Type: "object",
-							Properties: map[string]apiextensionsv1.JSONSchemaProps{
-								"spec": {
-									Type: "object",
-									Properties: map[string]apiextensionsv1.JSONSchemaProps{
-										"foo": {Type: "string"},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-			Scope: apiextensionsv1.NamespaceScoped,
-			Names: apiextensionsv1.CustomResourceDefinitionNames{
-				Plural:   "foos",
-				Singular: "foo",
-				Kind:     "Foo",
-				ListKind: "FooList",
-			},
-		},
+// opencontrolplane-gen:replace Foo=KIND
+// applyManagedCRDs applies the CRDs of the domain service to the MCP cluster in order and stops at the first error.
+// opencontrolplane-gen:replace Foo=KIND
+func (r *FooReconciler) applyManagedCRDs(ctx context.Context, svcobj *apiv1alpha1.Foo, pc *apiv1alpha1.ProviderConfig, c client.Client, managedObjs []*apiextensionsv1.CustomResourceDefinition, inv *inventory) error {
+	for _, managedObj := range managedObjs {
+		if err := r.applyManagedCRD(ctx, svcobj, pc, c, managedObj, inv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// applyManagedCRD applies a CRD of the domain service to the MCP cluster and adds it to the inventory.
+// Conflicting field owners are reported in the status of svcobj and returned as conflict error.
+// opencontrolplane-gen:replace Foo=KIND
+func (r *FooReconciler) applyManagedCRD(ctx context.Context, svcobj *apiv1alpha1.Foo, pc *apiv1alpha1.ProviderConfig, c client.Client, managedObj *apiextensionsv1.CustomResourceDefinition, inv *inventory) error {
+	l := logf.FromContext(ctx)
+	existing := &apiextensionsv1.CustomResourceDefinition{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(managedObj), existing); client.IgnoreNotFound(err) != nil {
+		l.Error(err, "get managed object failed")
+		statusDegraded(svcobj, apiv1alpha1.ReasonApplyFailed, err)
+		return err
+	}
+	drifted := hasDrifted(existing)
+	if err := applyObject(ctx, c, managedObj, r.fieldManager(), pc.GetForceConflicts()); err != nil {
+		if apierrors.IsConflict(err) {
+			l.Info("managed object has conflicting field owners", "name", managedObj.Name, "error", err.Error())
+			r.warningEvent(svcobj, managedObj, eventReasonFieldConflict, eventActionApply,
+				"CustomResourceDefinition %s has fields owned by other field managers, set forceConflicts in the ProviderConfig to take them over", managedObj.Name)
+			setCondition(svcobj, apiv1alpha1.ConditionTypeManagedResourcesApplied, metav1.ConditionFalse, apiv1alpha1.ReasonFieldConflict, err.Error())
+			statusDegraded(svcobj, apiv1alpha1.ReasonFieldConflict, err)
+			return err
+		}
+		l.Error(err, "apply failed")
+		setCondition(svcobj, apiv1alpha1.ConditionTypeManagedResourcesApplied, metav1.ConditionFalse, apiv1alpha1.ReasonApplyFailed, err.Error())
+		statusDegraded(svcobj, apiv1alpha1.ReasonApplyFailed, err)
+		return err
+	}
+	if err := markApplied(ctx, c, managedObj); err != nil {
+		l.Error(err, "recording applied generation failed")
+		statusDegraded(svcobj, apiv1alpha1.ReasonApplyFailed, err)
+		return err
+	}
+	if err := inv.add(c, apiv1alpha1.InventoryClusterMCP, managedObj); err != nil {
+		statusDegraded(svcobj, apiv1alpha1.ReasonApplyFailed, err)
+		return err
+	}
+	if drifted {
+		l.Info("managed object was modified on the MCP cluster and has been reapplied", "name", managedObj.Name)
 	}
+	r.managedObjectEvent(svcobj, existing, managedObj, drifted)
+	return nil
 }
 
 // opencontrolplane-gen:fi
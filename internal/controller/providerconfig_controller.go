@@ -0,0 +1,204 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/openmcp-project/controller-utils/pkg/clusters"
+	commonapi "github.com/openmcp-project/openmcp-operator/api/common"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/domainservice"
+)
+
+// providerConfigRequeueInterval is used to re-validate ProviderConfigs, e.g. to notice referenced secrets that were created later on.
+const providerConfigRequeueInterval = time.Minute
+
+// maxUsedBy limits the number of service objects that are listed in the status of a ProviderConfig.
+const maxUsedBy = 10
+
+// ProviderConfigReconciler validates ProviderConfig objects and reports their status.
+type ProviderConfigReconciler struct {
+	// PlatformCluster is the cluster where the ProviderConfig resources are located.
+	PlatformCluster *clusters.Cluster
+	// OnboardingCluster is the cluster where the service objects using the ProviderConfig are located.
+	OnboardingCluster *clusters.Cluster
+	// PodNamespace is the namespace where secrets referenced by the ProviderConfig are looked up.
+	PodNamespace string
+	// ProviderName is the name of the provider. Service objects are reconciled with the ProviderConfig of the same name.
+	ProviderName string
+}
+
+// Reconcile validates the ProviderConfig and updates its status.
+func (r *ProviderConfigReconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
+	l := logf.FromContext(ctx)
+	pc := &apiv1alpha1.ProviderConfig{}
+	if err := r.PlatformCluster.Client().Get(ctx, req.NamespacedName, pc); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !pc.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	orig := pc.DeepCopy()
+
+	errs := pc.ValidateSpec()
+	secretErrs, err := r.validateSecretReferences(ctx, pc)
+	if err != nil {
+		l.Error(err, "validating secret references failed")
+		return ctrl.Result{}, err
+	}
+	errs = append(errs, secretErrs...)
+	versionErrs, err := validateVersions(pc)
+	if err != nil {
+		l.Error(err, "validating domain service versions failed")
+		return ctrl.Result{}, err
+	}
+	errs = append(errs, versionErrs...)
+	if len(errs) > 0 {
+		msg := errs.ToAggregate().Error()
+		setCondition(pc, apiv1alpha1.ConditionTypeAvailable, metav1.ConditionFalse, apiv1alpha1.ReasonInvalidSpec, msg)
+		setCondition(pc, apiv1alpha1.ConditionTypeDegraded, metav1.ConditionTrue, apiv1alpha1.ReasonInvalidSpec, msg)
+	} else {
+		setCondition(pc, apiv1alpha1.ConditionTypeAvailable, metav1.ConditionTrue, apiv1alpha1.ReasonValid, "ProviderConfig is valid")
+		setCondition(pc, apiv1alpha1.ConditionTypeDegraded, metav1.ConditionFalse, apiv1alpha1.ReasonValid, "ProviderConfig is valid")
+	}
+	pc.Status.ObservedGeneration = pc.Generation
+
+	usedBy, count, err := r.usedBy(ctx, pc)
+	if err != nil {
+		l.Error(err, "listing service objects failed")
+		return ctrl.Result{}, err
+	}
+	pc.Status.UsedBy = usedBy
+	pc.Status.UsedByCount = count
+
+	if !equality.Semantic.DeepEqual(orig.Status, pc.Status) {
+		if err := r.PlatformCluster.Client().Status().Patch(ctx, pc, client.MergeFrom(orig)); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+	}
+	return ctrl.Result{RequeueAfter: providerConfigRequeueInterval}, nil
+}
+
+// validateSecretReferences checks that all secrets referenced by the ProviderConfig exist in the pod namespace.
+func (r *ProviderConfigReconciler) validateSecretReferences(ctx context.Context, pc *apiv1alpha1.ProviderConfig) (field.ErrorList, error) {
+	var errs field.ErrorList
+	for i, ref := range pc.Spec.ImagePullSecrets {
+		if ref.Name == "" {
+			continue
+		}
+		secret := &corev1.Secret{}
+		if err := r.PlatformCluster.Client().Get(ctx, ref.NamespacedName(r.PodNamespace), secret); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("unable to get secret %s/%s: %w", r.PodNamespace, ref.Name, err)
+			}
+			errs = append(errs, field.NotFound(field.NewPath("spec", "imagePullSecrets").Index(i).Child("name"),
+				fmt.Sprintf("%s/%s", r.PodNamespace, ref.Name)))
+		}
+	}
+	return errs, nil
+}
+
+// validateVersions checks that the domain service versions referenced by the ProviderConfig are known to the service provider.
+func validateVersions(pc *apiv1alpha1.ProviderConfig) (field.ErrorList, error) {
+	known, err := domainservice.Versions()
+	if err != nil {
+		return nil, err
+	}
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	for i, v := range pc.Spec.Versions {
+		if !slices.Contains(known, v) {
+			errs = append(errs, field.NotSupported(specPath.Child("versions").Index(i), v, known))
+		}
+	}
+	if v := pc.Spec.DefaultVersion; v != "" && !slices.Contains(known, v) {
+		errs = append(errs, field.NotSupported(specPath.Child("defaultVersion"), v, known))
+	}
+	return errs, nil
+}
+
+// usedBy returns references to the first service objects that are reconciled with the given ProviderConfig,
+// sorted by namespace and name, and the number of all of them.
+func (r *ProviderConfigReconciler) usedBy(ctx context.Context, pc *apiv1alpha1.ProviderConfig) ([]commonapi.ObjectReference, int32, error) {
+	if pc.Name != r.ProviderName {
+		return nil, 0, nil
+	}
+	// opencontrolplane-gen:replace Foo=KIND
+	list := &apiv1alpha1.FooList{}
+	if err := r.OnboardingCluster.Client().List(ctx, list); err != nil {
+		return nil, 0, err
+	}
+	refs := make([]commonapi.ObjectReference, 0, len(list.Items))
+	for _, item := range list.Items {
+		refs = append(refs, commonapi.ObjectReference{
+			Name:      item.Name,
+			Namespace: item.Namespace,
+		})
+	}
+	slices.SortFunc(refs, func(a, b commonapi.ObjectReference) int {
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	if len(refs) > maxUsedBy {
+		refs = refs[:maxUsedBy]
+	}
+	return refs, int32(len(list.Items)), nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// ProviderConfigs are watched on the platform cluster, service objects on the cluster of the manager.
+func (r *ProviderConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// only creation and deletion of service objects change the usage of the ProviderConfig
+	usageChanged := predicate.Funcs{
+		UpdateFunc: func(event.UpdateEvent) bool { return false },
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("providerconfig").
+		WatchesRawSource(source.Kind(r.PlatformCluster.Cluster().GetCache(), &apiv1alpha1.ProviderConfig{},
+			&handler.TypedEnqueueRequestForObject[*apiv1alpha1.ProviderConfig]{})).
+		// opencontrolplane-gen:replace Foo=KIND
+		Watches(&apiv1alpha1.Foo{}, handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: r.ProviderName}}}
+		}), builder.WithPredicates(usageChanged)).
+		Complete(r)
+}
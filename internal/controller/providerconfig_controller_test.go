@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/openmcp-project/controller-utils/pkg/clusters"
+	commonapi "github.com/openmcp-project/openmcp-operator/api/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+func TestProviderConfigUsedBy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := apiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	// created in reverse order to check the sorting
+	objs := make([]client.Object, 0, maxUsedBy+2)
+	for i := maxUsedBy + 1; i >= 0; i-- {
+		// opencontrolplane-gen:replace Foo=KIND
+		objs = append(objs, &apiv1alpha1.Foo{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("obj-%02d", i), Namespace: "project"}})
+	}
+	want := make([]commonapi.ObjectReference, 0, maxUsedBy)
+	for i := range maxUsedBy {
+		want = append(want, commonapi.ObjectReference{Name: fmt.Sprintf("obj-%02d", i), Namespace: "project"})
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	r := &ProviderConfigReconciler{OnboardingCluster: clusters.NewTestClusterFromClient("onboarding", c), ProviderName: "foo"}
+
+	tests := []struct {
+		name      string
+		pc        string
+		want      []commonapi.ObjectReference
+		wantCount int32
+	}{
+		{name: "first service objects and count", pc: "foo", want: want, wantCount: int32(len(objs))},
+		{name: "ProviderConfig of another provider", pc: "other"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &apiv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: tt.pc}}
+			got, count, err := r.usedBy(context.Background(), pc)
+			if err != nil {
+				t.Fatalf("usedBy() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) || count != tt.wantCount {
+				t.Errorf("usedBy() = %v, %d, want %v, %d", got, count, tt.want, tt.wantCount)
+			}
+		})
+	}
+}
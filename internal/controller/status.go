@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package controller
+
+import (
+	commonapi "github.com/openmcp-project/openmcp-operator/api/common"
+	meta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// conditionObject is an object that exposes its status conditions.
+type conditionObject interface {
+	client.Object
+	GetConditions() *[]metav1.Condition
+}
+
+// statusObject is an object with a phase and an observed generation in addition to its conditions.
+type statusObject interface {
+	conditionObject
+	SetPhase(phase string)
+	SetObservedGeneration(gen int64)
+}
+
+// setCondition sets the given condition on the object.
+// The lastTransitionTime is only updated if the status of the condition changes.
+func setCondition(obj conditionObject, conType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(obj.GetConditions(), metav1.Condition{
+		Type:               conType,
+		Status:             status,
+		ObservedGeneration: obj.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// opencontrolplane-gen:if SAMPLECODE=true
+// statusProgressing marks the object as not ready while a reconciliation is in progress.
+func statusProgressing(obj statusObject, reason, message string) {
+	setCondition(obj, apiv1alpha1.ConditionTypeReady, metav1.ConditionFalse, reason, message)
+	obj.SetPhase(commonapi.StatusPhaseProgressing)
+	obj.SetObservedGeneration(obj.GetGeneration())
+}
+
+// statusReady marks the object as ready and clears a previously reported failure.
+func statusReady(obj statusObject) {
+	setCondition(obj, apiv1alpha1.ConditionTypeReady, metav1.ConditionTrue, apiv1alpha1.ReasonReconciled, "service is ready")
+	setCondition(obj, apiv1alpha1.ConditionTypeDegraded, metav1.ConditionFalse, apiv1alpha1.ReasonReconciled, "last reconciliation succeeded")
+	obj.SetPhase(commonapi.StatusPhaseReady)
+	obj.SetObservedGeneration(obj.GetGeneration())
+}
+
+// opencontrolplane-gen:fi
+// statusDegraded reports a failed reconciliation with the text of the underlying error.
+func statusDegraded(obj statusObject, reason string, err error) {
+	setCondition(obj, apiv1alpha1.ConditionTypeDegraded, metav1.ConditionTrue, reason, err.Error())
+	setCondition(obj, apiv1alpha1.ConditionTypeReady, metav1.ConditionFalse, reason, err.Error())
+	obj.SetObservedGeneration(obj.GetGeneration())
+}
+
+// statusTerminating marks the object as not ready while it is being deleted.
+func statusTerminating(obj statusObject) {
+	setCondition(obj, apiv1alpha1.ConditionTypeReady, metav1.ConditionFalse, apiv1alpha1.ReasonTerminating, "service is being deleted")
+	obj.SetPhase(commonapi.StatusPhaseTerminating)
+	obj.SetObservedGeneration(obj.GetGeneration())
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+// opencontrolplane-gen:if SAMPLECODE=true
+import (
+	"errors"
+	"testing"
+	"time"
+
+	commonapi "github.com/openmcp-project/openmcp-operator/api/common"
+	meta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+func TestStatusTransitions(t *testing.T) {
+	// opencontrolplane-gen:replace Foo=KIND
+	obj := &apiv1alpha1.Foo{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", Generation: 2}}
+	steps := []struct {
+		name          string
+		update        func()
+		wantPhase     string
+		wantReady     metav1.ConditionStatus
+		wantReason    string
+		wantDegraded  metav1.ConditionStatus
+		keepsReadyLTT bool
+	}{
+		{
+			name:       "progressing",
+			update:     func() { statusProgressing(obj, apiv1alpha1.ReasonWaitingForAccess, "waiting for MCP access") },
+			wantPhase:  commonapi.StatusPhaseProgressing,
+			wantReady:  metav1.ConditionFalse,
+			wantReason: apiv1alpha1.ReasonWaitingForAccess,
+		},
+		{
+			name:          "degraded keeps the phase and the transition time of Ready",
+			update:        func() { statusDegraded(obj, apiv1alpha1.ReasonApplyFailed, errors.New("apply failed")) },
+			wantPhase:     commonapi.StatusPhaseProgressing,
+			wantReady:     metav1.ConditionFalse,
+			wantReason:    apiv1alpha1.ReasonApplyFailed,
+			wantDegraded:  metav1.ConditionTrue,
+			keepsReadyLTT: true,
+		},
+		{
+			name:         "ready clears the failure",
+			update:       func() { statusReady(obj) },
+			wantPhase:    commonapi.StatusPhaseReady,
+			wantReady:    metav1.ConditionTrue,
+			wantReason:   apiv1alpha1.ReasonReconciled,
+			wantDegraded: metav1.ConditionFalse,
+		},
+		{
+			name:         "terminating",
+			update:       func() { statusTerminating(obj) },
+			wantPhase:    commonapi.StatusPhaseTerminating,
+			wantReady:    metav1.ConditionFalse,
+			wantReason:   apiv1alpha1.ReasonTerminating,
+			wantDegraded: metav1.ConditionFalse,
+		},
+	}
+	for _, step := range steps {
+		var readyLTT metav1.Time
+		if con := meta.FindStatusCondition(obj.Status.Conditions, apiv1alpha1.ConditionTypeReady); con != nil {
+			// move the last transition back to detect whether it is updated
+			con.LastTransitionTime = metav1.NewTime(con.LastTransitionTime.Add(-time.Hour))
+			readyLTT = con.LastTransitionTime
+		}
+		step.update()
+
+		if obj.Status.Phase != step.wantPhase {
+			t.Errorf("%s: phase = %s, want %s", step.name, obj.Status.Phase, step.wantPhase)
+		}
+		if obj.Status.ObservedGeneration != obj.Generation {
+			t.Errorf("%s: observedGeneration = %d, want %d", step.name, obj.Status.ObservedGeneration, obj.Generation)
+		}
+		ready := meta.FindStatusCondition(obj.Status.Conditions, apiv1alpha1.ConditionTypeReady)
+		if ready == nil || ready.Status != step.wantReady || ready.Reason != step.wantReason || ready.ObservedGeneration != obj.Generation {
+			t.Errorf("%s: Ready condition = %+v, want status %s and reason %s", step.name, ready, step.wantReady, step.wantReason)
+			continue
+		}
+		if step.keepsReadyLTT && !ready.LastTransitionTime.Equal(&readyLTT) {
+			t.Errorf("%s: lastTransitionTime of Ready changed without a status change", step.name)
+		}
+		if step.wantDegraded == "" {
+			continue
+		}
+		if degraded := meta.FindStatusCondition(obj.Status.Conditions, apiv1alpha1.ConditionTypeDegraded); degraded == nil || degraded.Status != step.wantDegraded {
+			t.Errorf("%s: Degraded condition = %+v, want status %s", step.name, degraded, step.wantDegraded)
+		}
+	}
+}
+
+// opencontrolplane-gen:fi
@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package controller
+
+// opencontrolplane-gen:if SAMPLECODE=true
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/domainservice"
+)
+
+// rolloutRequeueInterval is used to revisit objects while the CRDs of a new domain service version are not established yet.
+const rolloutRequeueInterval = 5 * time.Second
+
+// availableVersions returns the versions of the domain service that may be installed, sorted from the oldest to the latest version.
+// The versions known to the service provider are restricted to the versions listed in the ProviderConfig, if any.
+func availableVersions(pc *apiv1alpha1.ProviderConfig) ([]string, error) {
+	versions, err := domainservice.Versions()
+	if err != nil || pc == nil || len(pc.Spec.Versions) == 0 {
+		return versions, err
+	}
+	return slices.DeleteFunc(versions, func(v string) bool { return !slices.Contains(pc.Spec.Versions, v) }), nil
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// targetVersion returns the version of the domain service to install for obj: the version requested in its spec,
+// the default version of the ProviderConfig or the latest available version, in this order.
+// An error is returned if the version is not available or cannot be reached from the installed version.
+// opencontrolplane-gen:replace Foo=KIND
+func targetVersion(obj *apiv1alpha1.Foo, pc *apiv1alpha1.ProviderConfig) (string, error) {
+	available, err := availableVersions(pc)
+	if err != nil {
+		return "", err
+	}
+	target := obj.Spec.Version
+	if target == "" && pc != nil {
+		target = pc.Spec.DefaultVersion
+	}
+	if target == "" && len(available) > 0 {
+		target = available[len(available)-1]
+	}
+	if !slices.Contains(available, target) {
+		return "", fmt.Errorf("%w %q, available versions: %s", domainservice.ErrUnknownVersion, target, strings.Join(available, ", "))
+	}
+	if err := domainservice.CheckUpgrade(obj.Status.InstalledVersion, target); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// versionReason returns the condition reason for an error of targetVersion.
+func versionReason(err error) string {
+	if errors.Is(err, domainservice.ErrUpgradeNotAllowed) {
+		return apiv1alpha1.ReasonUpgradeNotAllowed
+	}
+	return apiv1alpha1.ReasonInvalidVersion
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// startRollout reports the installation or upgrade of the domain service to the target version with the Progressing condition.
+// opencontrolplane-gen:replace Foo=KIND
+func startRollout(obj *apiv1alpha1.Foo, target string) {
+	switch installed := obj.Status.InstalledVersion; installed {
+	case target:
+	case "":
+		setCondition(obj, apiv1alpha1.ConditionTypeProgressing, metav1.ConditionTrue, apiv1alpha1.ReasonInstalling,
+			fmt.Sprintf("installing domain service version %s", target))
+	default:
+		setCondition(obj, apiv1alpha1.ConditionTypeProgressing, metav1.ConditionTrue, apiv1alpha1.ReasonUpgrading,
+			fmt.Sprintf("upgrading domain service from version %s to %s", installed, target))
+	}
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// completeRollout records the target version as installed once the applied CRDs of the domain service are established
+// and reports whether the rollout is complete.
+// opencontrolplane-gen:replace Foo=KIND
+func completeRollout(obj *apiv1alpha1.Foo, target string, crds []*apiextensionsv1.CustomResourceDefinition) bool {
+	if obj.Status.InstalledVersion != target && !slices.ContainsFunc(crds, notEstablished) {
+		obj.Status.InstalledVersion = target
+	}
+	if obj.Status.InstalledVersion != target {
+		return false
+	}
+	setCondition(obj, apiv1alpha1.ConditionTypeProgressing, metav1.ConditionFalse, apiv1alpha1.ReasonVersionInstalled,
+		fmt.Sprintf("domain service version %s is installed", target))
+	return true
+}
+
+// notEstablished reports whether the API server does not serve the versions of crd yet.
+func notEstablished(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	for _, c := range crd.Status.Conditions {
+		if c.Type == apiextensionsv1.Established {
+			return c.Status != apiextensionsv1.ConditionTrue
+		}
+	}
+	return true
+}
+
+// opencontrolplane-gen:fi
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+// opencontrolplane-gen:if SAMPLECODE=true
+import (
+	"errors"
+	"testing"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/domainservice"
+)
+
+func TestTargetVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		installed  string
+		pc         *apiv1alpha1.ProviderConfig
+		want       string
+		wantErr    error
+		wantReason string
+	}{
+		{name: "latest version without ProviderConfig", want: "v1.1.0"},
+		{name: "requested version", version: "v1.0.0", want: "v1.0.0"},
+		{
+			name: "default version of the ProviderConfig",
+			pc:   &apiv1alpha1.ProviderConfig{Spec: apiv1alpha1.ProviderConfigSpec{DefaultVersion: "v1.0.0"}},
+			want: "v1.0.0",
+		},
+		{
+			name:    "requested version takes precedence over the default version",
+			version: "v1.1.0",
+			pc:      &apiv1alpha1.ProviderConfig{Spec: apiv1alpha1.ProviderConfigSpec{DefaultVersion: "v1.0.0"}},
+			want:    "v1.1.0",
+		},
+		{
+			name: "latest version allowed by the ProviderConfig",
+			pc:   &apiv1alpha1.ProviderConfig{Spec: apiv1alpha1.ProviderConfigSpec{Versions: []string{"v1.0.0"}}},
+			want: "v1.0.0",
+		},
+		{
+			name:       "version not allowed by the ProviderConfig",
+			version:    "v1.1.0",
+			pc:         &apiv1alpha1.ProviderConfig{Spec: apiv1alpha1.ProviderConfigSpec{Versions: []string{"v1.0.0"}}},
+			wantErr:    domainservice.ErrUnknownVersion,
+			wantReason: apiv1alpha1.ReasonInvalidVersion,
+		},
+		{
+			name:       "unknown version",
+			version:    "v9.9.9",
+			wantErr:    domainservice.ErrUnknownVersion,
+			wantReason: apiv1alpha1.ReasonInvalidVersion,
+		},
+		{name: "upgrade", version: "v1.1.0", installed: "v1.0.0", want: "v1.1.0"},
+		{
+			name:       "downgrade",
+			version:    "v1.0.0",
+			installed:  "v1.1.0",
+			wantErr:    domainservice.ErrUpgradeNotAllowed,
+			wantReason: apiv1alpha1.ReasonUpgradeNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// opencontrolplane-gen:replace Foo=KIND
+			obj := &apiv1alpha1.Foo{}
+			obj.Spec.Version = tt.version
+			obj.Status.InstalledVersion = tt.installed
+			got, err := targetVersion(obj, tt.pc)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("targetVersion() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("targetVersion() = %q, want %q", got, tt.want)
+			}
+			if err != nil && versionReason(err) != tt.wantReason {
+				t.Errorf("versionReason() = %s, want %s", versionReason(err), tt.wantReason)
+			}
+		})
+	}
+}
+
+// opencontrolplane-gen:fi
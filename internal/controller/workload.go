@@ -0,0 +1,310 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package controller
+
+// opencontrolplane-gen:if WORKLOADCLUSTER=true
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/openmcp-project/controller-utils/pkg/clusters"
+	ctrlutils "github.com/openmcp-project/controller-utils/pkg/controller"
+	clusteraccess "github.com/openmcp-project/opencontrolplane-runtime/pkg/serviceprovider/clusteraccess"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/domainservice"
+)
+
+const (
+	// opencontrolplane-gen:replace foo=KIND_LOWER
+	// serviceControllerName is the name of the objects that run the domain service controller in the workload cluster.
+	// opencontrolplane-gen:replace foo=KIND_LOWER
+	serviceControllerName = "foo-controller"
+	// serviceControllerMetricsPort is the port the domain service controller serves its metrics on.
+	serviceControllerMetricsPort = 8080
+)
+
+// serviceControllerRules are the permissions of the domain service controller in its namespace in the workload cluster,
+// it uses them for leader election and to record events. Everything else is done with the kubeconfig for the MCP cluster.
+var serviceControllerRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{"coordination.k8s.io"},
+		Resources: []string{"leases"},
+		Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+	},
+	{
+		APIGroups: []string{""},
+		Resources: []string{"events"},
+		Verbs:     []string{"create", "patch"},
+	},
+}
+
+// serviceControllerObjectRules are the permissions required to deploy the domain service controller to its namespace
+// in the workload cluster, they are granted by a Role in that namespace.
+var serviceControllerObjectRules = []rbacv1.PolicyRule{
+	{
+		APIGroups: []string{""},
+		Resources: []string{"secrets", "serviceaccounts", "services"},
+		Verbs:     []string{"create", "get", "update", "patch", "delete"},
+	},
+	{
+		APIGroups: []string{rbacv1.GroupName},
+		Resources: []string{"roles", "rolebindings"},
+		Verbs:     []string{"create", "get", "update", "patch", "delete"},
+	},
+	{
+		APIGroups: []string{appsv1.GroupName},
+		Resources: []string{"deployments"},
+		Verbs:     []string{"create", "get", "update", "patch", "delete"},
+	},
+}
+
+// ClusterAccessFunc returns the access to a cluster for the service object of the request.
+type ClusterAccessFunc func(ctx context.Context, req reconcile.Request) (*clusters.Cluster, error)
+
+// ServiceControllerMCPPermissions returns the permissions the domain service controller requires on the MCP cluster.
+// They are requested by an access request of their own, so that the domain service controller does not run with
+// the permissions of the service provider, which manages the CRDs.
+// Extend the manifest when the domain service controller manages additional objects.
+func ServiceControllerMCPPermissions(scheme *runtime.Scheme) *PermissionManifest {
+	m := NewPermissionManifest(scheme)
+	// every version of the domain service may be installed, so the permissions cover the CRDs of all versions
+	managedCRDs, err := domainservice.AllCRDs()
+	if err != nil {
+		m.errs = append(m.errs, err)
+	}
+	return m.ReconcilesCustomResources(managedCRDs...)
+}
+
+// serviceControllerNamespaceRule returns the permissions required to read and delete the namespace of the domain service controller.
+// The namespace is created by the access request for the workload cluster, which requests the Role in it,
+// so the service provider neither creates nor updates it.
+func serviceControllerNamespaceRule(namespace string) rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{
+		APIGroups:     []string{""},
+		Resources:     []string{"namespaces"},
+		ResourceNames: []string{namespace},
+		Verbs:         []string{"get", "delete"},
+	}
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// workloadNamespace returns the namespace in the workload cluster that the domain service controller of the Foo runs in.
+// opencontrolplane-gen:replace Foo=KIND
+func workloadNamespace(obj *apiv1alpha1.Foo) string {
+	// opencontrolplane-gen:replace foo=KIND_LOWER
+	return "foo-" + ctrlutils.ObjectHashSHAKE128Base32(obj)
+}
+
+// serviceControllerImage returns the image of the domain service controller for the given version of the domain service.
+// The version is used as tag if the image has neither a tag nor a digest.
+func serviceControllerImage(image, version string) string {
+	name := image[strings.LastIndex(image, "/")+1:]
+	if version == "" || strings.ContainsAny(name, ":@") {
+		return image
+	}
+	return image + ":" + version
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// deployServiceController applies the domain service controller of the Foo to a namespace in the workload cluster
+// and adds its objects to the inventory. The controller manages the domain service with a kubeconfig for the MCP cluster
+// with the credentials of its own access request, whose token is rotated as the secret is applied again on every reconcile.
+// Nothing is deployed if the ProviderConfig does not specify an image, objects deployed before are pruned then.
+// opencontrolplane-gen:replace Foo=KIND
+func (r *FooReconciler) deployServiceController(ctx context.Context, svcobj *apiv1alpha1.Foo, pc *apiv1alpha1.ProviderConfig, clusters clusteraccess.ClusterContext, inv *inventory) error {
+	if pc == nil || pc.Spec.ServiceControllerImage == "" {
+		return nil
+	}
+	if clusters.WorkloadCluster == nil || !clusters.WorkloadCluster.HasClient() {
+		return fmt.Errorf("access to the workload cluster has not been granted yet")
+	}
+	kubeconfig, err := r.serviceControllerKubeconfig(ctx, svcobj)
+	if err != nil {
+		return err
+	}
+	image := serviceControllerImage(pc.Spec.ServiceControllerImage, svcobj.Status.InstalledVersion)
+	c := clusters.WorkloadCluster.Client()
+	if err := addServiceControllerNamespace(ctx, c, svcobj, inv); err != nil {
+		return err
+	}
+	for _, obj := range serviceControllerObjects(svcobj, image, kubeconfig) {
+		if err := applyObject(ctx, c, obj, r.fieldManager(), pc.GetForceConflicts()); err != nil {
+			return fmt.Errorf("unable to apply %s to the workload cluster: %w", client.ObjectKeyFromObject(obj), err)
+		}
+		if err := inv.add(c, apiv1alpha1.InventoryClusterWorkload, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyFailedReason returns the reason for the Degraded condition if the objects could not be applied to the workload cluster.
+func applyFailedReason(err error) string {
+	if apierrors.IsConflict(err) {
+		return apiv1alpha1.ReasonFieldConflict
+	}
+	return apiv1alpha1.ReasonApplyFailed
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// serviceControllerKubeconfig returns the kubeconfig for the MCP cluster with the credentials of the access request of the domain service controller.
+// opencontrolplane-gen:replace Foo=KIND
+func (r *FooReconciler) serviceControllerKubeconfig(ctx context.Context, svcobj *apiv1alpha1.Foo) (*MCPKubeconfig, error) {
+	if r.ServiceControllerAccess == nil {
+		return nil, fmt.Errorf("no access to the MCP cluster is configured for the domain service controller")
+	}
+	mcp, err := r.ServiceControllerAccess(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(svcobj)})
+	if err != nil {
+		return nil, fmt.Errorf("access to the MCP cluster for the domain service controller has not been granted yet: %w", err)
+	}
+	return NewMCPKubeconfig(mcp, workloadNamespace(svcobj))
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// addServiceControllerNamespace adds the namespace of the domain service controller of the Foo to the inventory.
+// The namespace is created by the access request for the workload cluster and is only read, it is added first
+// so that the inventory deletes it after the objects in it.
+// opencontrolplane-gen:replace Foo=KIND
+func addServiceControllerNamespace(ctx context.Context, c client.Client, svcobj *apiv1alpha1.Foo, inv *inventory) error {
+	namespace := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: workloadNamespace(svcobj)}, namespace); err != nil {
+		return fmt.Errorf("unable to get namespace %s of the domain service controller: %w", workloadNamespace(svcobj), err)
+	}
+	return inv.add(c, apiv1alpha1.InventoryClusterWorkload, namespace)
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// serviceControllerObjects returns the objects that run the domain service controller of the Foo in its namespace
+// in the workload cluster in the order they are applied. The inventory deletes them in reverse order.
+// opencontrolplane-gen:replace Foo=KIND
+func serviceControllerObjects(svcobj *apiv1alpha1.Foo, image string, kubeconfig *MCPKubeconfig) []client.Object {
+	namespace := workloadNamespace(svcobj)
+	labels := map[string]string{
+		"app.kubernetes.io/name":       serviceControllerName,
+		"app.kubernetes.io/instance":   namespace,
+		"app.kubernetes.io/managed-by": apiv1alpha1.GroupVersion.Group,
+	}
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels}
+	}
+	secret := kubeconfig.Secret()
+	secret.Labels = labels
+	return []client.Object{
+		secret,
+		&corev1.ServiceAccount{
+			ObjectMeta: meta(serviceControllerName),
+		},
+		&rbacv1.Role{
+			ObjectMeta: meta(serviceControllerName),
+			Rules:      serviceControllerRules,
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: meta(serviceControllerName),
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     serviceControllerName,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      serviceControllerName,
+					Namespace: namespace,
+				},
+			},
+		},
+		serviceControllerDeployment(meta(serviceControllerName), image, kubeconfig),
+		&corev1.Service{
+			ObjectMeta: meta(serviceControllerName),
+			Spec: corev1.ServiceSpec{
+				Selector: labels,
+				Ports: []corev1.ServicePort{
+					{
+						Name:       "metrics",
+						Port:       serviceControllerMetricsPort,
+						TargetPort: intstr.FromString("metrics"),
+					},
+				},
+			},
+		},
+	}
+}
+
+// serviceControllerDeployment returns the deployment of the domain service controller.
+// The controller reads the kubeconfig for the MCP cluster from the mounted secret.
+func serviceControllerDeployment(meta metav1.ObjectMeta, image string, kubeconfig *MCPKubeconfig) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: meta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{MatchLabels: meta.Labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: meta.Labels},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: meta.Name,
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot:   ptr.To(true),
+						SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:  "manager",
+							Image: image,
+							Args:  []string{"--leader-elect"},
+							Env: []corev1.EnvVar{
+								{
+									Name:      "POD_NAMESPACE",
+									ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
+								},
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "metrics",
+									ContainerPort: serviceControllerMetricsPort,
+								},
+							},
+							SecurityContext: &corev1.SecurityContext{
+								AllowPrivilegeEscalation: ptr.To(false),
+								ReadOnlyRootFilesystem:   ptr.To(true),
+								Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	kubeconfig.Mount(&deployment.Spec.Template)
+	return deployment
+}
+
+// opencontrolplane-gen:fi
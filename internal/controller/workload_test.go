@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+// opencontrolplane-gen:if WORKLOADCLUSTER=true
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/openmcp-project/controller-utils/pkg/clusters"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/domainservice"
+)
+
+// opencontrolplane-gen:replace Foo=KIND
+var testWorkloadFoo = &apiv1alpha1.Foo{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "project-test"}}
+
+// allows reports whether one of the rules grants the verb on the resource.
+func allows(rules []rbacv1.PolicyRule, group, resource, verb string) bool {
+	return slices.ContainsFunc(rules, func(r rbacv1.PolicyRule) bool {
+		return slices.Contains(r.APIGroups, group) && slices.Contains(r.Resources, resource) && slices.Contains(r.Verbs, verb)
+	})
+}
+
+// testServiceControllerObjects returns the objects of the domain service controller of testWorkloadFoo.
+func testServiceControllerObjects(t *testing.T) []client.Object {
+	t.Helper()
+	mcp := clusters.New("mcp").WithRESTConfig(&rest.Config{Host: "https://mcp.example.com", BearerToken: "token"})
+	kubeconfig, err := NewMCPKubeconfig(mcp, workloadNamespace(testWorkloadFoo))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return serviceControllerObjects(testWorkloadFoo, "example.com/foo-controller:v1.0.0", kubeconfig)
+}
+
+func TestServiceControllerObjects(t *testing.T) {
+	namespace := workloadNamespace(testWorkloadFoo)
+	var kinds []string
+	for _, obj := range testServiceControllerObjects(t) {
+		if obj.GetNamespace() != namespace {
+			t.Errorf("%T %s is not in namespace %s", obj, obj.GetName(), namespace)
+		}
+		if _, ok := obj.(*corev1.Namespace); ok {
+			t.Errorf("the namespace is created by the access request and must not be applied")
+		}
+		gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+		kinds = append(kinds, gvk.Kind)
+	}
+	// the secret is mounted by the deployment and the role is bound before the deployment is applied
+	want := []string{"Secret", "ServiceAccount", "Role", "RoleBinding", "Deployment", "Service"}
+	if !slices.Equal(kinds, want) {
+		t.Errorf("serviceControllerObjects() kinds = %v, want %v", kinds, want)
+	}
+}
+
+func TestWorkloadPermissions(t *testing.T) {
+	namespace := workloadNamespace(testWorkloadFoo)
+	cfg, err := WorkloadPermissions(scheme.Scheme, types.NamespacedName{Name: testWorkloadFoo.Name, Namespace: testWorkloadFoo.Namespace}).TokenConfig()
+	if err != nil {
+		t.Fatalf("TokenConfig() error = %v", err)
+	}
+	if len(cfg.Permissions) != 2 || cfg.Permissions[0].Namespace != "" || cfg.Permissions[1].Namespace != namespace {
+		t.Fatalf("TokenConfig().Permissions = %+v, want cluster-wide rules and rules in namespace %s", cfg.Permissions, namespace)
+	}
+	clusterRules, namespacedRules := cfg.Permissions[0].Rules, cfg.Permissions[1].Rules
+	for _, rule := range clusterRules {
+		if !slices.Equal(rule.Resources, []string{"namespaces"}) || !slices.Equal(rule.ResourceNames, []string{namespace}) {
+			t.Errorf("cluster-wide rule %+v is not restricted to namespace %s", rule, namespace)
+		}
+	}
+	for _, verb := range []string{"get", "delete"} {
+		if !allows(clusterRules, "", "namespaces", verb) {
+			t.Errorf("%s of namespace %s is not allowed", verb, namespace)
+		}
+	}
+	// server-side apply creates missing objects and patches existing ones
+	for _, obj := range testServiceControllerObjects(t) {
+		gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		for _, verb := range []string{"get", "create", "patch", "delete"} {
+			if !allows(namespacedRules, gvr.Group, gvr.Resource, verb) {
+				t.Errorf("%s of %s is not allowed in namespace %s", verb, gvr.Resource, namespace)
+			}
+		}
+	}
+	// the permissions of the domain service controller are held, so that its role can be created
+	for _, rule := range serviceControllerRules {
+		if !slices.ContainsFunc(namespacedRules, func(r rbacv1.PolicyRule) bool { return equalRules(r, rule) }) {
+			t.Errorf("rule %+v of the domain service controller is not held", rule)
+		}
+	}
+}
+
+func TestAddServiceControllerNamespace(t *testing.T) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: workloadNamespace(testWorkloadFoo), UID: "uid"}}
+	tests := []struct {
+		name        string
+		objects     []client.Object
+		wantErr     bool
+		wantEntries []apiv1alpha1.InventoryEntry
+	}{
+		{
+			name:    "namespace not created yet",
+			wantErr: true,
+		},
+		{
+			name:    "namespace is added to the inventory",
+			objects: []client.Object{namespace},
+			wantEntries: []apiv1alpha1.InventoryEntry{
+				{Cluster: apiv1alpha1.InventoryClusterWorkload, Version: "v1", Kind: "Namespace", Name: namespace.Name, UID: namespace.UID},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(tt.objects...).Build()
+			inv := &inventory{}
+			err := addServiceControllerNamespace(context.Background(), c, testWorkloadFoo, inv)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("addServiceControllerNamespace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(inv.entries, tt.wantEntries) {
+				t.Errorf("inventory entries = %+v, want %+v", inv.entries, tt.wantEntries)
+			}
+		})
+	}
+}
+
+func TestServiceControllerMCPPermissions(t *testing.T) {
+	rules, err := ServiceControllerMCPPermissions(nil).Rules()
+	if err != nil {
+		t.Fatalf("Rules() error = %v", err)
+	}
+	crds, err := domainservice.AllCRDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, crd := range crds {
+		for _, verb := range []string{"get", "list", "watch", "update", "patch"} {
+			if !allows(rules, crd.Spec.Group, crd.Spec.Names.Plural, verb) {
+				t.Errorf("%s of %s is not allowed", verb, crd.Spec.Names.Plural)
+			}
+		}
+		if !allows(rules, crd.Spec.Group, crd.Spec.Names.Plural+"/status", "patch") {
+			t.Errorf("patch of the status of %s is not allowed", crd.Spec.Names.Plural)
+		}
+	}
+	// the domain service controller must neither manage the CRDs nor delete the objects of its users
+	for _, rule := range rules {
+		if slices.Contains(rule.Resources, "customresourcedefinitions") || slices.Contains(rule.Verbs, "delete") || slices.Contains(rule.Verbs, "create") {
+			t.Errorf("rule %+v grants more than the domain service controller requires", rule)
+		}
+	}
+}
+
+// opencontrolplane-gen:fi
@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package domainservice provides the CRDs of the domain service that the service provider installs on the MCP clusters.
+// The CRDs of every released version of the domain service are embedded from manifests/<version>.
+package domainservice
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+
+	crdutil "github.com/openmcp-project/controller-utils/pkg/crds"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// ManifestFS contains the manifests of all versions of the domain service.
+//
+//go:embed manifests
+var ManifestFS embed.FS
+
+// manifestsDir is the directory in ManifestFS that contains one directory per version of the domain service.
+const manifestsDir = "manifests"
+
+var (
+	// ErrUnknownVersion is returned for versions of the domain service without manifests.
+	ErrUnknownVersion = errors.New("unknown domain service version")
+	// ErrUpgradeNotAllowed is returned for version changes of the domain service that are not a supported upgrade.
+	ErrUpgradeNotAllowed = errors.New("domain service upgrade not allowed")
+)
+
+// Versions returns the versions of the domain service, sorted from the oldest to the latest version.
+func Versions() ([]string, error) {
+	entries, err := fs.ReadDir(ManifestFS, manifestsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", manifestsDir, err)
+	}
+	parsed := map[string]*version.Version{}
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		v, err := version.ParseSemantic(entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("invalid domain service version %s: %w", entry.Name(), err)
+		}
+		parsed[entry.Name()] = v
+		versions = append(versions, entry.Name())
+	}
+	slices.SortFunc(versions, func(a, b string) int {
+		switch {
+		case parsed[a].LessThan(parsed[b]):
+			return -1
+		case parsed[b].LessThan(parsed[a]):
+			return 1
+		}
+		return 0
+	})
+	return versions, nil
+}
+
+// LatestVersion returns the latest version of the domain service.
+func LatestVersion() (string, error) {
+	versions, err := Versions()
+	if err != nil {
+		return "", err
+	}
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no domain service versions found in %s", manifestsDir)
+	}
+	return versions[len(versions)-1], nil
+}
+
+// CRDs returns the CRDs of the given version of the domain service.
+// The latest version is used if v is empty.
+func CRDs(v string) ([]*apiextv1.CustomResourceDefinition, error) {
+	versions, err := Versions()
+	if err != nil {
+		return nil, err
+	}
+	if v == "" && len(versions) > 0 {
+		v = versions[len(versions)-1]
+	}
+	if !slices.Contains(versions, v) {
+		return nil, fmt.Errorf("%w %q, available versions: %s", ErrUnknownVersion, v, strings.Join(versions, ", "))
+	}
+	return crdutil.CRDsFromFileSystem(ManifestFS, path.Join(manifestsDir, v))
+}
+
+// AllCRDs returns the CRDs of all versions of the domain service, each CRD once in the manifest of the latest version
+// that contains it. Permissions and cleanup must cover these CRDs, as any of them may be installed on an MCP cluster.
+func AllCRDs() ([]*apiextv1.CustomResourceDefinition, error) {
+	versions, err := Versions()
+	if err != nil {
+		return nil, err
+	}
+	var all []*apiextv1.CustomResourceDefinition
+	for _, v := range versions {
+		crds, err := CRDs(v)
+		if err != nil {
+			return nil, err
+		}
+		for _, crd := range crds {
+			i := slices.IndexFunc(all, func(c *apiextv1.CustomResourceDefinition) bool { return c.Name == crd.Name })
+			if i < 0 {
+				all = append(all, crd)
+				continue
+			}
+			all[i] = crd
+		}
+	}
+	return all, nil
+}
+
+// CheckUpgrade returns an error if the domain service cannot be changed from the installed version to the target version.
+// Downgrades are rejected, as are upgrades that skip a major version, since the CRDs of a major version
+// are only guaranteed to serve the API versions stored by the previous major version.
+func CheckUpgrade(installed, target string) error {
+	if installed == "" || installed == target {
+		return nil
+	}
+	from, err := version.ParseSemantic(installed)
+	if err != nil {
+		return fmt.Errorf("invalid installed domain service version %s: %w", installed, err)
+	}
+	to, err := version.ParseSemantic(target)
+	if err != nil {
+		return fmt.Errorf("invalid domain service version %s: %w", target, err)
+	}
+	if to.LessThan(from) {
+		return fmt.Errorf("%w: downgrade from %s to %s is not supported", ErrUpgradeNotAllowed, installed, target)
+	}
+	if to.Major() > from.Major()+1 {
+		return fmt.Errorf("%w: upgrade from %s to %s skips a major version, upgrade to a v%d.x.x version first", ErrUpgradeNotAllowed, installed, target, from.Major()+1)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domainservice
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func TestVersions(t *testing.T) {
+	versions, err := Versions()
+	if err != nil {
+		t.Fatalf("Versions() error = %v", err)
+	}
+	if want := []string{"v1.0.0", "v1.1.0"}; !slices.Equal(versions, want) {
+		t.Errorf("Versions() = %v, want %v", versions, want)
+	}
+	latest, err := LatestVersion()
+	if err != nil {
+		t.Fatalf("LatestVersion() error = %v", err)
+	}
+	if latest != versions[len(versions)-1] {
+		t.Errorf("LatestVersion() = %s, want %s", latest, versions[len(versions)-1])
+	}
+}
+
+func TestCRDs(t *testing.T) {
+	latest, err := LatestVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		version     string
+		wantVersion string
+		wantErr     error
+	}{
+		{name: "version of the manifests", version: "v1.0.0", wantVersion: "v1.0.0"},
+		{name: "latest version if empty", version: "", wantVersion: latest},
+		{name: "unknown version", version: "v0.9.0", wantErr: ErrUnknownVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			crds, err := CRDs(tt.version)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CRDs() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			want, err := CRDs(tt.wantVersion)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(crds) == 0 || !slices.EqualFunc(crds, want, sameCRD) {
+				t.Errorf("CRDs(%q) = %v, want the CRDs of %s", tt.version, crdNames(crds), tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestAllCRDs(t *testing.T) {
+	all, err := AllCRDs()
+	if err != nil {
+		t.Fatalf("AllCRDs() error = %v", err)
+	}
+	versions, err := Versions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every CRD is contained once, in the manifest of the latest version that contains it
+	for i, v := range versions {
+		crds, err := CRDs(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, crd := range crds {
+			got := slices.IndexFunc(all, func(c *apiextv1.CustomResourceDefinition) bool { return c.Name == crd.Name })
+			if got < 0 {
+				t.Errorf("AllCRDs() does not contain CRD %s of version %s", crd.Name, v)
+				continue
+			}
+			if i == len(versions)-1 && !sameCRD(all[got], crd) {
+				t.Errorf("AllCRDs() contains CRD %s of another version than %s", crd.Name, v)
+			}
+		}
+	}
+	names := crdNames(all)
+	slices.Sort(names)
+	if len(slices.Compact(names)) != len(all) {
+		t.Errorf("AllCRDs() contains CRDs more than once: %v", crdNames(all))
+	}
+}
+
+// sameCRD returns true if both CRDs have the same name and versions.
+func sameCRD(a, b *apiextv1.CustomResourceDefinition) bool {
+	return a.Name == b.Name && slices.EqualFunc(a.Spec.Versions, b.Spec.Versions, func(x, y apiextv1.CustomResourceDefinitionVersion) bool {
+		return x.Name == y.Name && x.Served == y.Served && x.Storage == y.Storage
+	})
+}
+
+func crdNames(crds []*apiextv1.CustomResourceDefinition) []string {
+	names := make([]string, 0, len(crds))
+	for _, crd := range crds {
+		names = append(names, crd.Name)
+	}
+	return names
+}
+
+func TestCheckUpgrade(t *testing.T) {
+	tests := []struct {
+		name      string
+		installed string
+		target    string
+		wantErr   error
+	}{
+		{name: "installation", target: "v1.0.0"},
+		{name: "same version", installed: "v1.1.0", target: "v1.1.0"},
+		{name: "minor upgrade", installed: "v1.0.0", target: "v1.1.0"},
+		{name: "upgrade to the next major version", installed: "v1.1.0", target: "v2.0.0"},
+		{name: "downgrade", installed: "v1.1.0", target: "v1.0.0", wantErr: ErrUpgradeNotAllowed},
+		{name: "upgrade skipping a major version", installed: "v1.1.0", target: "v3.0.0", wantErr: ErrUpgradeNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckUpgrade(tt.installed, tt.target); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckUpgrade(%q, %q) error = %v, want %v", tt.installed, tt.target, err, tt.wantErr)
+			}
+		})
+	}
+	if err := CheckUpgrade("latest", "v1.0.0"); err == nil {
+		t.Errorf("CheckUpgrade() of an invalid installed version succeeded")
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// cacheSyncTimeout is the time a readiness check waits for informer caches to be synced.
+const cacheSyncTimeout = time.Second
+
+// APIServer returns a check that fails if the API server of the cluster cannot be reached with the credentials of cfg.
+// The version endpoint is requested, which every authenticated user may read,
+// so the check also fails if the credentials have expired or have been revoked.
+func APIServer(cfg *rest.Config) (healthz.Checker, error) {
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return func(req *http.Request) error {
+		return dc.RESTClient().Get().AbsPath("/version").Do(req.Context()).Error()
+	}, nil
+}
+
+// TokenValidity returns a check that fails if the bearer token of cfg expires within minValidity.
+// Tokens that are no JWTs or have no expiration claim are considered valid.
+func TokenValidity(cfg *rest.Config, minValidity time.Duration) healthz.Checker {
+	return func(_ *http.Request) error {
+		token := cfg.BearerToken
+		if len(cfg.BearerTokenFile) > 0 {
+			data, err := os.ReadFile(cfg.BearerTokenFile)
+			if err != nil {
+				return fmt.Errorf("unable to read token file: %w", err)
+			}
+			token = strings.TrimSpace(string(data))
+		}
+		exp, ok := tokenExpiration(token)
+		if !ok {
+			return nil
+		}
+		if remaining := time.Until(exp); remaining < minValidity {
+			return fmt.Errorf("token expires at %s", exp.Format(time.RFC3339))
+		}
+		return nil
+	}
+}
+
+// tokenExpiration returns the expiration claim of a JWT.
+func tokenExpiration(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	claims := struct {
+		Exp int64 `json:"exp"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Exp, 0), true
+}
+
+// CRDs returns a check that fails unless the expected CRDs are established in the cluster of the reader
+// and serve all versions of the expected CRDs with the same storage version.
+// A CRD that is missing a version has not been updated by the init command of this version of the service provider yet.
+// Without a conversion webhook, the init command only serves the storage version, so the other versions are expected not to be served.
+func CRDs(reader client.Reader, expected ...*apiextensionsv1.CustomResourceDefinition) healthz.Checker {
+	return func(req *http.Request) error {
+		var errs []error
+		for _, want := range expected {
+			if err := checkCRD(req.Context(), reader, want); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+func checkCRD(ctx context.Context, reader client.Reader, want *apiextensionsv1.CustomResourceDefinition) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := reader.Get(ctx, client.ObjectKey{Name: want.Name}, crd); err != nil {
+		return fmt.Errorf("unable to get CRD %s: %w", want.Name, err)
+	}
+	if !established(crd) {
+		return fmt.Errorf("CRD %s is not established", want.Name)
+	}
+	converted := crd.Spec.Conversion != nil && crd.Spec.Conversion.Strategy == apiextensionsv1.WebhookConverter
+	for _, wv := range want.Spec.Versions {
+		v := version(crd, wv.Name)
+		if v == nil || v.Served != (wv.Served && (converted || wv.Storage)) || v.Storage != wv.Storage {
+			return fmt.Errorf("CRD %s does not serve version %s as expected", want.Name, wv.Name)
+		}
+	}
+	return nil
+}
+
+func established(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	for _, c := range crd.Status.Conditions {
+		if c.Type == apiextensionsv1.Established {
+			return c.Status == apiextensionsv1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func version(crd *apiextensionsv1.CustomResourceDefinition, name string) *apiextensionsv1.CustomResourceDefinitionVersion {
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Name == name {
+			return &crd.Spec.Versions[i]
+		}
+	}
+	return nil
+}
+
+// CacheSynced returns a check that fails until the informer caches of the cache have been synced.
+func CacheSynced(cache interface {
+	WaitForCacheSync(ctx context.Context) bool
+}) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), cacheSyncTimeout)
+		defer cancel()
+		if !cache.WaitForCacheSync(ctx) {
+			return errors.New("informer caches are not synced")
+		}
+		return nil
+	}
+}
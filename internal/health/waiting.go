@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package health provides the health probes of the service provider.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// WaitingProbes serve the liveness and readiness probes while the service provider waits for a dependency
+// before its manager is created, e.g. for access to the onboarding cluster. The liveness probe succeeds,
+// so that the pod is not restarted while waiting, and the readiness probe fails with the reason.
+// The probes must be stopped before the manager takes over the probe address.
+type WaitingProbes struct {
+	// Addr is the probe address. The probes are not served if it is empty or "0", like the manager does.
+	Addr string
+	// Reason is reported by the readiness probe, e.g. "waiting for onboarding access".
+	Reason string
+
+	mu      sync.Mutex
+	lastErr error
+	server  *http.Server
+}
+
+// Start serves the probes in the background.
+func (p *WaitingProbes) Start() error {
+	if p.Addr == "" || p.Addr == "0" {
+		return nil
+	}
+	ln, err := net.Listen("tcp", p.Addr)
+	if err != nil {
+		return fmt.Errorf("unable to listen on probe address %s: %w", p.Addr, err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "ok")
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, p.message(), http.StatusServiceUnavailable)
+	})
+	p.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		_ = p.server.Serve(ln)
+	}()
+	return nil
+}
+
+// SetError records the error of the last attempt to obtain the dependency, it is reported along with the reason.
+func (p *WaitingProbes) SetError(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lastErr = err
+}
+
+// Stop stops serving the probes and releases the probe address.
+func (p *WaitingProbes) Stop(ctx context.Context) error {
+	if p.server == nil {
+		return nil
+	}
+	if err := p.server.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (p *WaitingProbes) message() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.lastErr != nil {
+		return fmt.Sprintf("%s: %v", p.Reason, p.lastErr)
+	}
+	return p.Reason
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// freeAddr returns a local address that is not in use.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	if err := ln.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return addr
+}
+
+func probe(t *testing.T, url string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestWaitingProbes(t *testing.T) {
+	p := &WaitingProbes{Addr: freeAddr(t), Reason: "waiting for onboarding access"}
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if code, _ := probe(t, "http://"+p.Addr+"/healthz"); code != http.StatusOK {
+		t.Errorf("liveness probe status = %d, want %d", code, http.StatusOK)
+	}
+	p.SetError(errors.New("access request not granted"))
+	code, body := probe(t, "http://"+p.Addr+"/readyz")
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("readiness probe status = %d, want %d", code, http.StatusServiceUnavailable)
+	}
+	if !strings.Contains(body, "waiting for onboarding access: access request not granted") {
+		t.Errorf("readiness probe body = %q, want the reason and the last error", body)
+	}
+
+	if err := p.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	// the manager takes over the probe address after the probes have been stopped
+	ln, err := net.Listen("tcp", p.Addr)
+	if err != nil {
+		t.Fatalf("probe address has not been released: %v", err)
+	}
+	_ = ln.Close()
+}
+
+func TestWaitingProbesDisabled(t *testing.T) {
+	p := &WaitingProbes{Addr: "0"}
+	if err := p.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := p.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// workqueueDepthMetric is the gauge controller-runtime reports the number of items waiting in the workqueue of a controller with.
+const workqueueDepthMetric = "workqueue_depth"
+
+// Watchdog detects a wedged reconcile loop. Reconcilers track each reconcile,
+// the liveness check fails once a reconcile has been running for longer than the timeout
+// or items have been waiting in the workqueue without any reconcile completing for longer than the timeout,
+// so that the pod is restarted.
+type Watchdog struct {
+	// Timeout is the duration after which a running reconcile is considered to be stuck.
+	Timeout time.Duration
+	// Queued returns the number of items waiting to be reconciled, it is optional.
+	// Without it, only reconciles that have started are checked.
+	Queued func() (int, error)
+
+	mu            sync.Mutex
+	next          uint64
+	running       map[uint64]time.Time
+	lastCompleted time.Time
+}
+
+// Track marks the start of a reconcile, the returned function must be called when it has finished.
+// It is safe to call Track on a nil Watchdog.
+func (w *Watchdog) Track() func() {
+	if w == nil {
+		return func() {}
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.running == nil {
+		w.running = map[uint64]time.Time{}
+	}
+	id := w.next
+	w.next++
+	w.running[id] = time.Now()
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.running, id)
+		w.lastCompleted = time.Now()
+	}
+}
+
+// Check is a liveness check that fails if a reconcile has been running for longer than the timeout
+// or if items are queued and no reconcile has completed within the timeout.
+func (w *Watchdog) Check(_ *http.Request) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, start := range w.running {
+		if d := time.Since(start); d > w.Timeout {
+			return fmt.Errorf("a reconcile has been running for %s", d.Round(time.Second))
+		}
+	}
+	return w.checkQueue()
+}
+
+// checkQueue fails if items are queued and no reconcile has completed within the timeout.
+// The time of the first check counts as last completion, so that the workers have the timeout to start.
+func (w *Watchdog) checkQueue() error {
+	if w.Queued == nil {
+		return nil
+	}
+	if w.lastCompleted.IsZero() {
+		w.lastCompleted = time.Now()
+	}
+	queued, err := w.Queued()
+	if err != nil {
+		return fmt.Errorf("unable to determine the queued items: %w", err)
+	}
+	if d := time.Since(w.lastCompleted); queued > 0 && d > w.Timeout {
+		return fmt.Errorf("%d items are queued and no reconcile has completed for %s", queued, d.Round(time.Second))
+	}
+	return nil
+}
+
+// WorkqueueDepth returns a function for Watchdog.Queued that reads the number of items in the workqueue
+// of the named controller from the workqueue metrics that controller-runtime registers with gatherer.
+func WorkqueueDepth(gatherer prometheus.Gatherer, controller string) func() (int, error) {
+	return func() (int, error) {
+		families, err := gatherer.Gather()
+		if err != nil {
+			return 0, err
+		}
+		var depth float64
+		for _, family := range families {
+			if family.GetName() != workqueueDepthMetric {
+				continue
+			}
+			for _, m := range family.GetMetric() {
+				for _, label := range m.GetLabel() {
+					if label.GetName() == "controller" && label.GetValue() == controller {
+						depth += m.GetGauge().GetValue()
+					}
+				}
+			}
+		}
+		return int(depth), nil
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestWatchdogCheck(t *testing.T) {
+	const timeout = time.Minute
+	queued := func(n int, err error) func() (int, error) {
+		return func() (int, error) { return n, err }
+	}
+	tests := []struct {
+		name          string
+		running       time.Duration
+		queued        func() (int, error)
+		lastCompleted time.Duration
+		wantErr       bool
+	}{
+		{name: "idle"},
+		{name: "reconcile running within the timeout", running: timeout / 2},
+		{name: "reconcile running for longer than the timeout", running: 2 * timeout, wantErr: true},
+		{name: "queued items with a recent completion", queued: queued(3, nil), lastCompleted: timeout / 2},
+		{name: "queued items without a completion within the timeout", queued: queued(3, nil), lastCompleted: 2 * timeout, wantErr: true},
+		{name: "empty queue without a completion within the timeout", queued: queued(0, nil), lastCompleted: 2 * timeout},
+		{name: "queue depth unknown", queued: queued(0, errors.New("gather failed")), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Watchdog{Timeout: timeout, Queued: tt.queued}
+			if tt.running > 0 {
+				w.Track()
+				w.running[0] = time.Now().Add(-tt.running)
+			}
+			if tt.lastCompleted > 0 {
+				w.lastCompleted = time.Now().Add(-tt.lastCompleted)
+			}
+			if err := w.Check(nil); (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWatchdogTrack(t *testing.T) {
+	w := &Watchdog{Timeout: time.Minute, Queued: func() (int, error) { return 1, nil }}
+	w.lastCompleted = time.Now().Add(-time.Hour)
+	done := w.Track()
+	w.running[0] = time.Now().Add(-time.Hour)
+	if err := w.Check(nil); err == nil {
+		t.Fatal("Check() of a stuck reconcile succeeded")
+	}
+	done()
+	// the completed reconcile is no longer running and counts as progress of the queue
+	if err := w.Check(nil); err != nil {
+		t.Errorf("Check() after the reconcile completed error = %v", err)
+	}
+
+	var nilWatchdog *Watchdog
+	nilWatchdog.Track()()
+}
+
+func TestWorkqueueDepth(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	depth := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: workqueueDepthMetric}, []string{"name", "controller", "priority"})
+	registry.MustRegister(depth)
+	depth.WithLabelValues("foo", "foo", "0").Set(2)
+	depth.WithLabelValues("foo", "foo", "1").Set(1)
+	depth.WithLabelValues("bar", "bar", "0").Set(5)
+
+	tests := []struct {
+		controller string
+		want       int
+	}{
+		{controller: "foo", want: 3},
+		{controller: "bar", want: 5},
+		{controller: "unknown", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.controller, func(t *testing.T) {
+			got, err := WorkqueueDepth(registry, tt.controller)()
+			if err != nil {
+				t.Fatalf("WorkqueueDepth() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("WorkqueueDepth() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
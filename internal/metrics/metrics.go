@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics provides the Prometheus metrics of the service provider.
+// The metrics are registered with the controller-runtime registry and served by the metrics server of the manager.
+//
+//go:generate opencontrolplane-gen
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "service_provider"
+	// opencontrolplane-gen:replace foo=KIND_LOWER
+	metricsSubsystem = "foo"
+)
+
+const (
+	// OperationCreateOrUpdate labels reconciles of service objects that are not being deleted.
+	OperationCreateOrUpdate = "create_or_update"
+	// OperationDelete labels reconciles of service objects that are being deleted.
+	OperationDelete = "delete"
+)
+
+var (
+	// reconcileDuration is the duration of reconciles per MCP and operation.
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "reconcile_duration_seconds",
+		Help:      "Duration of the reconciles of service objects per MCP and operation.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"mcp", "operation"})
+
+	// accessRequestWait is the time spent waiting for access requests of the cluster access manager to be granted.
+	accessRequestWait = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "access_request_wait_seconds",
+		Help:      "Time spent waiting for access requests to be granted per request and result.",
+		Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800},
+	}, []string{"request", "result"})
+
+	// applyErrors counts the failed applies of managed objects per GVK.
+	applyErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "managed_object_apply_errors_total",
+		Help:      "Number of failed applies of managed objects per group, version and kind.",
+	}, []string{"group", "version", "kind"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(reconcileDuration, accessRequestWait, applyErrors)
+}
+
+// ObserveReconcile marks the start of a reconcile of the service object that belongs to the MCP with the given namespace and name.
+// The returned function records the duration of the reconcile and must be called when it has finished.
+func ObserveReconcile(operation, namespace, name string) func() {
+	start := time.Now()
+	return func() {
+		reconcileDuration.WithLabelValues(namespace+"/"+name, operation).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ForgetObject removes the reconcile durations of the service object with the given namespace and name.
+// It must be called once the deletion of the service object has completed, so that the series of deleted objects are not reported forever.
+func ForgetObject(namespace, name string) {
+	reconcileDuration.DeletePartialMatch(prometheus.Labels{"mcp": namespace + "/" + name})
+}
+
+// ObserveAccessRequestWait records the time spent waiting for the access request with the given name.
+func ObserveAccessRequestWait(request string, d time.Duration, err error) {
+	result := "granted"
+	if err != nil {
+		result = "failed"
+	}
+	accessRequestWait.WithLabelValues(request, result).Observe(d.Seconds())
+}
+
+// RecordApplyError counts a failed apply of a managed object.
+func RecordApplyError(gvk schema.GroupVersionKind) {
+	applyErrors.WithLabelValues(gvk.Group, gvk.Version, gvk.Kind).Inc()
+}
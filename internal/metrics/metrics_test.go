@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestForgetObject(t *testing.T) {
+	reconcileDuration.Reset()
+	ObserveReconcile(OperationCreateOrUpdate, "mcp-a", "foo")()
+	ObserveReconcile(OperationDelete, "mcp-a", "foo")()
+	ObserveReconcile(OperationCreateOrUpdate, "mcp-b", "foo")()
+	if n := testutil.CollectAndCount(reconcileDuration); n != 3 {
+		t.Fatalf("series = %d, want 3", n)
+	}
+
+	// all operations of the deleted object are removed, other objects are kept
+	ForgetObject("mcp-a", "foo")
+	if n := testutil.CollectAndCount(reconcileDuration); n != 1 {
+		t.Errorf("series after ForgetObject = %d, want 1", n)
+	}
+	ForgetObject("mcp-a", "foo")
+	if n := testutil.CollectAndCount(reconcileDuration); n != 1 {
+		t.Errorf("series after forgetting a deleted object twice = %d, want 1", n)
+	}
+}
+
+func TestObserveAccessRequestWait(t *testing.T) {
+	accessRequestWait.Reset()
+	ObserveAccessRequestWait("mcp", time.Second, nil)
+	ObserveAccessRequestWait("mcp", time.Minute, errors.New("timeout"))
+	ObserveAccessRequestWait("onboarding", time.Second, nil)
+	if n := testutil.CollectAndCount(accessRequestWait); n != 3 {
+		t.Errorf("series = %d, want one per request and result", n)
+	}
+}
+
+func TestRecordApplyError(t *testing.T) {
+	applyErrors.Reset()
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	RecordApplyError(gvk)
+	RecordApplyError(gvk)
+	if v := testutil.ToFloat64(applyErrors.WithLabelValues("apps", "v1", "Deployment")); v != 2 {
+		t.Errorf("apply errors = %v, want 2", v)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	meta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// collectTimeout limits the time spent on listing the service objects per scrape.
+const collectTimeout = 10 * time.Second
+
+// objectCollector reports the state of the service objects at scrape time.
+type objectCollector struct {
+	reader         client.Reader
+	objects        *prometheus.Desc
+	blocked        *prometheus.Desc
+	blockedSeconds *prometheus.Desc
+}
+
+// RegisterObjectCollector registers the metrics about the service objects, which are listed with the reader on every scrape.
+// The reader should be backed by the cache of the manager.
+func RegisterObjectCollector(reader client.Reader) error {
+	return ctrlmetrics.Registry.Register(newObjectCollector(reader))
+}
+
+func newObjectCollector(reader client.Reader) *objectCollector {
+	return &objectCollector{
+		reader: reader,
+		objects: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "objects"),
+			"Number of service objects per phase.", []string{"phase"}, nil),
+		blocked: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "deletion_blocked_objects"),
+			"Number of service objects whose deletion is blocked.", nil, nil),
+		blockedSeconds: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "deletion_blocked_seconds"),
+			"Time since the deletion of a service object has been blocked.", []string{"namespace", "name"}, nil),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *objectCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.objects
+	ch <- c.blocked
+	ch <- c.blockedSeconds
+}
+
+// Collect implements prometheus.Collector.
+func (c *objectCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+	// opencontrolplane-gen:replace Foo=KIND
+	list := &apiv1alpha1.FooList{}
+	if err := c.reader.List(ctx, list); err != nil {
+		ch <- prometheus.NewInvalidMetric(c.objects, err)
+		return
+	}
+	phases := map[string]int{}
+	blocked := 0
+	for _, obj := range list.Items {
+		phase := obj.Status.Phase
+		if phase == "" {
+			phase = "Unknown"
+		}
+		phases[phase]++
+		con := meta.FindStatusCondition(obj.Status.Conditions, apiv1alpha1.ConditionTypeDeletionBlocked)
+		if obj.DeletionTimestamp == nil || con == nil || con.Status != metav1.ConditionTrue {
+			continue
+		}
+		blocked++
+		ch <- prometheus.MustNewConstMetric(c.blockedSeconds, prometheus.GaugeValue,
+			time.Since(con.LastTransitionTime.Time).Seconds(), obj.Namespace, obj.Name)
+	}
+	for phase, n := range phases {
+		ch <- prometheus.MustNewConstMetric(c.objects, prometheus.GaugeValue, float64(n), phase)
+	}
+	ch <- prometheus.MustNewConstMetric(c.blocked, prometheus.GaugeValue, float64(blocked))
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// testObject returns a service object in the given phase, which is being deleted if the deletion is blocked.
+func testObject(name, phase string, deletionBlocked bool) client.Object {
+	// opencontrolplane-gen:replace Foo=KIND
+	obj := &apiv1alpha1.Foo{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "project"}}
+	obj.Status.Phase = phase
+	if deletionBlocked {
+		obj.Finalizers = []string{"test"}
+		obj.DeletionTimestamp = &metav1.Time{Time: metav1.Now().Time}
+		obj.Status.Conditions = []metav1.Condition{{
+			Type:               apiv1alpha1.ConditionTypeDeletionBlocked,
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+		}}
+	}
+	return obj
+}
+
+func TestObjectCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := apiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		testObject("ready-1", "Ready", false),
+		testObject("ready-2", "Ready", false),
+		testObject("new", "", false),
+		testObject("deleting", "Terminating", true),
+	).Build()
+
+	collector := newObjectCollector(c)
+	objects := prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "objects")
+	blocked := prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "deletion_blocked_objects")
+	expected := fmt.Sprintf(`
+# HELP %[2]s Number of service objects whose deletion is blocked.
+# TYPE %[2]s gauge
+%[2]s 1
+# HELP %[1]s Number of service objects per phase.
+# TYPE %[1]s gauge
+%[1]s{phase="Ready"} 2
+%[1]s{phase="Terminating"} 1
+%[1]s{phase="Unknown"} 1
+`, objects, blocked)
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), objects, blocked); err != nil {
+		t.Error(err)
+	}
+	blockedSeconds := prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "deletion_blocked_seconds")
+	if n := testutil.CollectAndCount(collector, blockedSeconds); n != 1 {
+		t.Errorf("deletion blocked series = %d, want 1", n)
+	}
+}
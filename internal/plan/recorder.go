@@ -0,0 +1,229 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plan determines the changes a reconciliation would make to a cluster without mutating it.
+// All writes are sent as server-side dry-run requests, so defaulting, admission and conflicts are evaluated by the API server.
+package plan
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// Operation is the kind of change to an object.
+type Operation string
+
+const (
+	// OperationCreate creates an object that does not exist yet.
+	OperationCreate Operation = "create"
+	// OperationUpdate modifies an existing object.
+	OperationUpdate Operation = "update"
+	// OperationDelete deletes an existing object.
+	OperationDelete Operation = "delete"
+)
+
+// Change is a modification of an object that would be made by a write request.
+type Change struct {
+	// Operation is the kind of change.
+	Operation Operation
+	// Kind of the object.
+	Kind string
+	// Key of the object.
+	Key client.ObjectKey
+	// Diff is a unified diff between the current and the resulting object.
+	Diff string
+}
+
+// String returns a header line for the change followed by the diff.
+func (c Change) String() string {
+	name := c.Key.Name
+	if c.Key.Namespace != "" {
+		name = c.Key.String()
+	}
+	return fmt.Sprintf("%s %s %s\n%s", c.Operation, c.Kind, name, c.Diff)
+}
+
+// Recorder is a client that sends all writes as server-side dry-run requests and records the resulting changes.
+// Reads are passed to the wrapped client.
+type Recorder struct {
+	client.Client
+	changes []Change
+}
+
+// NewRecorder returns a Recorder that sends the writes for c as dry-run requests.
+func NewRecorder(c client.Client) *Recorder {
+	return &Recorder{Client: client.NewDryRunClient(c)}
+}
+
+// Changes returns the changes recorded so far, writes that would not change an object are omitted.
+func (r *Recorder) Changes() []Change {
+	return r.changes
+}
+
+// Create records the object that would be created.
+func (r *Recorder) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return r.record(ctx, obj, func() error { return r.Client.Create(ctx, obj, opts...) })
+}
+
+// Update records the changes of the update.
+func (r *Recorder) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return r.record(ctx, obj, func() error { return r.Client.Update(ctx, obj, opts...) })
+}
+
+// Patch records the changes of the patch.
+func (r *Recorder) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return r.record(ctx, obj, func() error { return r.Client.Patch(ctx, obj, patch, opts...) })
+}
+
+// Apply records the changes of the server-side apply request.
+// Only apply configurations that are backed by an unstructured object can be recorded.
+func (r *Recorder) Apply(ctx context.Context, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+	o, ok := obj.(client.Object)
+	if !ok {
+		return fmt.Errorf("unable to record apply configuration %T", obj)
+	}
+	return r.record(ctx, o, func() error { return r.Client.Apply(ctx, obj, opts...) })
+}
+
+// Delete records the object that would be deleted.
+func (r *Recorder) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	current, err := r.current(ctx, obj)
+	if err != nil {
+		return err
+	}
+	if err := r.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+	if current != nil {
+		return r.add(OperationDelete, obj, current, nil)
+	}
+	return nil
+}
+
+// record executes the dry-run write and records the difference between the object before and after the write.
+func (r *Recorder) record(ctx context.Context, obj client.Object, write func() error) error {
+	current, err := r.current(ctx, obj)
+	if err != nil {
+		return err
+	}
+	if err := write(); err != nil {
+		return err
+	}
+	result, err := r.unstructured(obj)
+	if err != nil {
+		return err
+	}
+	op := OperationUpdate
+	if current == nil {
+		op = OperationCreate
+	}
+	return r.add(op, obj, current, result)
+}
+
+// current returns the object as it is currently stored, nil if it does not exist.
+func (r *Recorder) current(ctx context.Context, obj client.Object) (*unstructured.Unstructured, error) {
+	gvk, err := r.GroupVersionKindFor(obj)
+	if err != nil {
+		return nil, err
+	}
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(gvk)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return current, nil
+}
+
+// unstructured converts the object into its unstructured representation.
+func (r *Recorder) unstructured(obj client.Object) (*unstructured.Unstructured, error) {
+	gvk, err := r.GroupVersionKindFor(obj)
+	if err != nil {
+		return nil, err
+	}
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	return u, nil
+}
+
+// add records the change between before and after, nil stands for an object that does not exist.
+func (r *Recorder) add(op Operation, obj client.Object, before, after *unstructured.Unstructured) error {
+	from, err := manifest(before)
+	if err != nil {
+		return err
+	}
+	to, err := manifest(after)
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return nil
+	}
+	gvk, err := r.GroupVersionKindFor(obj)
+	if err != nil {
+		return err
+	}
+	key := client.ObjectKeyFromObject(obj)
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: "current",
+		ToFile:   "planned",
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+	r.changes = append(r.changes, Change{Operation: op, Kind: gvk.Kind, Key: key, Diff: diff})
+	return nil
+}
+
+// manifest returns the YAML representation of the object without the fields that are maintained by the API server.
+func manifest(obj *unstructured.Unstructured) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+	obj = obj.DeepCopy()
+	for _, f := range [][]string{
+		{"status"},
+		{"metadata", "managedFields"},
+		{"metadata", "resourceVersion"},
+		{"metadata", "generation"},
+		{"metadata", "uid"},
+		{"metadata", "creationTimestamp"},
+	} {
+		unstructured.RemoveNestedField(obj.Object, f...)
+	}
+	data, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)) + "\n", nil
+}
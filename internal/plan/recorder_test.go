@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testConfigMap(name, value string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Data:       map[string]string{"key": value},
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		write     func(r *Recorder, existing *corev1.ConfigMap) error
+		wantOp    Operation
+		wantDiff  []string
+		noChanges bool
+	}{
+		{
+			name: "create",
+			write: func(r *Recorder, _ *corev1.ConfigMap) error {
+				return r.Create(ctx, testConfigMap("new", "value"))
+			},
+			wantOp:   OperationCreate,
+			wantDiff: []string{"+  key: value", "+  name: new"},
+		},
+		{
+			name: "update",
+			write: func(r *Recorder, existing *corev1.ConfigMap) error {
+				existing.Data["key"] = "new"
+				return r.Update(ctx, existing)
+			},
+			wantOp:   OperationUpdate,
+			wantDiff: []string{"-  key: old", "+  key: new"},
+		},
+		{
+			name: "update without changes",
+			write: func(r *Recorder, existing *corev1.ConfigMap) error {
+				return r.Update(ctx, existing)
+			},
+			noChanges: true,
+		},
+		{
+			name: "delete",
+			write: func(r *Recorder, existing *corev1.ConfigMap) error {
+				return r.Delete(ctx, existing)
+			},
+			wantOp:   OperationDelete,
+			wantDiff: []string{"-  key: old"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(testConfigMap("existing", "old")).Build()
+			existing := &corev1.ConfigMap{}
+			if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "existing"}, existing); err != nil {
+				t.Fatal(err)
+			}
+			r := NewRecorder(c)
+			if err := tt.write(r, existing); err != nil {
+				t.Fatalf("write error = %v", err)
+			}
+
+			changes := r.Changes()
+			if tt.noChanges {
+				if len(changes) != 0 {
+					t.Errorf("Changes() = %v, want none", changes)
+				}
+				return
+			}
+			if len(changes) != 1 {
+				t.Fatalf("Changes() = %v, want a single change", changes)
+			}
+			if changes[0].Operation != tt.wantOp || changes[0].Kind != "ConfigMap" {
+				t.Errorf("change = %s %s, want %s ConfigMap", changes[0].Operation, changes[0].Kind, tt.wantOp)
+			}
+			for _, line := range tt.wantDiff {
+				if !strings.Contains(changes[0].Diff, line) {
+					t.Errorf("diff does not contain %q:\n%s", line, changes[0].Diff)
+				}
+			}
+			if strings.Contains(changes[0].Diff, "resourceVersion") {
+				t.Errorf("diff contains fields maintained by the API server:\n%s", changes[0].Diff)
+			}
+		})
+	}
+}
+
+func TestRecorderDoesNotWrite(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithObjects(testConfigMap("existing", "old")).Build()
+	r := NewRecorder(c)
+	if err := r.Create(ctx, testConfigMap("new", "value")); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Delete(ctx, testConfigMap("existing", "old")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "new"}, &corev1.ConfigMap{}); !apierrors.IsNotFound(err) {
+		t.Errorf("planned object has been created, Get() error = %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "existing"}, &corev1.ConfigMap{}); err != nil {
+		t.Errorf("planned deletion has deleted the object: %v", err)
+	}
+}
+
+func TestChangeString(t *testing.T) {
+	tests := []struct {
+		change Change
+		want   string
+	}{
+		{
+			change: Change{Operation: OperationCreate, Kind: "ConfigMap", Key: client.ObjectKey{Namespace: "default", Name: "foo"}, Diff: "diff\n"},
+			want:   "create ConfigMap default/foo\ndiff\n",
+		},
+		{
+			change: Change{Operation: OperationDelete, Kind: "ClusterRole", Key: client.ObjectKey{Name: "foo"}, Diff: "diff\n"},
+			want:   "delete ClusterRole foo\ndiff\n",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.change.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
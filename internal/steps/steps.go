@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package steps runs a sequence of idempotent steps, such as the installation steps of the init command.
+// Failed steps are retried with a backoff, the sequence stops at the first step that keeps failing,
+// and the result of every step is kept for a summary of what has been changed.
+package steps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultBackoff retries a failed step five times within about a minute.
+var DefaultBackoff = wait.Backoff{
+	Duration: 2 * time.Second,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    6,
+}
+
+// Status is the outcome of a step.
+type Status string
+
+const (
+	// StatusSucceeded is the status of a step that has been run successfully.
+	StatusSucceeded Status = "Succeeded"
+	// StatusFailed is the status of a step that has failed on its last attempt.
+	StatusFailed Status = "Failed"
+	// StatusSkipped is the status of a step that has not been run because a previous step failed.
+	StatusSkipped Status = "Skipped"
+)
+
+// Step is a named unit of work.
+type Step struct {
+	// Name identifies the step in logs and the summary.
+	Name string
+	// Run executes the step and returns a description of each change it made.
+	// Run must be idempotent, it is called again if it returns an error.
+	Run func(ctx context.Context) ([]string, error)
+	// NoRetry runs the step only once, e.g. if it already waits and retries on its own.
+	NoRetry bool
+}
+
+// Result is the outcome of a step.
+type Result struct {
+	// Name is the name of the step.
+	Name string
+	// Status is the outcome of the step.
+	Status Status
+	// Attempts is the number of times the step has been run.
+	Attempts int
+	// Duration is the time spent on the step including retries.
+	Duration time.Duration
+	// Changes describe the changes made by the successful attempt.
+	Changes []string
+	// Err is the error of the last attempt of a failed step.
+	Err error
+}
+
+// Runner runs steps in order.
+type Runner struct {
+	// Backoff defines the retries of failed steps. DefaultBackoff is used if it is empty.
+	Backoff wait.Backoff
+}
+
+// Run runs the steps in order and stops at the first step that fails on all attempts.
+// It returns a result for every step, steps after a failed step are reported as skipped.
+func (r *Runner) Run(ctx context.Context, steps []Step) ([]Result, error) {
+	results := make([]Result, 0, len(steps))
+	var failed error
+	for _, step := range steps {
+		if failed != nil {
+			results = append(results, Result{Name: step.Name, Status: StatusSkipped})
+			continue
+		}
+		res := r.runStep(ctx, step)
+		results = append(results, res)
+		if res.Err != nil {
+			failed = fmt.Errorf("step %s failed after %d attempts: %w", step.Name, res.Attempts, res.Err)
+		}
+	}
+	return results, failed
+}
+
+// runStep runs a single step with retries.
+func (r *Runner) runStep(ctx context.Context, step Step) Result {
+	l := logf.FromContext(ctx).WithName("steps").WithValues("step", step.Name)
+	backoff := r.Backoff
+	if backoff.Steps == 0 {
+		backoff = DefaultBackoff
+	}
+	if step.NoRetry {
+		backoff.Steps = 1
+	}
+
+	res := Result{Name: step.Name}
+	start := time.Now()
+	l.Info("Running step")
+	err := wait.ExponentialBackoffWithContext(ctx, backoff, func(ctx context.Context) (bool, error) {
+		res.Attempts++
+		changes, err := step.Run(ctx)
+		if err != nil {
+			res.Err = err
+			l.Error(err, "Step failed", "attempt", res.Attempts)
+			return false, nil
+		}
+		res.Err = nil
+		res.Changes = changes
+		return true, nil
+	})
+	res.Duration = time.Since(start)
+	if err != nil && res.Err == nil {
+		// the context has been canceled before the step could be run
+		res.Err = err
+	}
+	if res.Err != nil {
+		res.Status = StatusFailed
+		return res
+	}
+	res.Status = StatusSucceeded
+	l.Info("Step succeeded", "attempts", res.Attempts, "changes", len(res.Changes))
+	return res
+}
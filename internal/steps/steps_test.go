@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package steps
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// failingStep returns a step that fails the given number of times before it succeeds.
+func failingStep(name string, failures int, calls *int) Step {
+	return Step{Name: name, Run: func(context.Context) ([]string, error) {
+		*calls++
+		if *calls <= failures {
+			return nil, errors.New("transient error")
+		}
+		return []string{name + " changed"}, nil
+	}}
+}
+
+func TestRunnerRun(t *testing.T) {
+	r := &Runner{Backoff: wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}}
+	tests := []struct {
+		name         string
+		failures     int
+		noRetry      bool
+		wantStatus   []Status
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "succeeds on the first attempt",
+			wantStatus:   []Status{StatusSucceeded, StatusSucceeded},
+			wantAttempts: 1,
+		},
+		{
+			name:         "succeeds after retries",
+			failures:     2,
+			wantStatus:   []Status{StatusSucceeded, StatusSucceeded},
+			wantAttempts: 3,
+		},
+		{
+			name:         "fails on all attempts and skips the remaining steps",
+			failures:     3,
+			wantStatus:   []Status{StatusFailed, StatusSkipped},
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "no retry runs the step once",
+			failures:     1,
+			noRetry:      true,
+			wantStatus:   []Status{StatusFailed, StatusSkipped},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var firstCalls, secondCalls int
+			first := failingStep("first", tt.failures, &firstCalls)
+			first.NoRetry = tt.noRetry
+			results, err := r.Run(context.Background(), []Step{first, failingStep("second", 0, &secondCalls)})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(results) != len(tt.wantStatus) {
+				t.Fatalf("Run() returned %d results, want %d", len(results), len(tt.wantStatus))
+			}
+			for i, res := range results {
+				if res.Status != tt.wantStatus[i] {
+					t.Errorf("step %s status = %s, want %s", res.Name, res.Status, tt.wantStatus[i])
+				}
+			}
+			if results[0].Attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", results[0].Attempts, tt.wantAttempts)
+			}
+			if tt.wantErr && secondCalls != 0 {
+				t.Errorf("skipped step has been run %d times", secondCalls)
+			}
+			if !tt.wantErr && len(results[0].Changes) != 1 {
+				t.Errorf("changes = %v, want the changes of the successful attempt", results[0].Changes)
+			}
+		})
+	}
+}
+
+func TestRunnerRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var calls int
+	results, err := (&Runner{}).Run(ctx, []Step{failingStep("first", 0, &calls)})
+	if err == nil {
+		t.Fatal("Run() with a canceled context succeeded")
+	}
+	if results[0].Status != StatusFailed || results[0].Err == nil {
+		t.Errorf("result = %+v, want a failed step with an error", results[0])
+	}
+}
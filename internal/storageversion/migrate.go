@@ -0,0 +1,148 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package storageversion migrates the objects of CRDs to the current storage version.
+// Once all objects are rewritten in the storage version, old versions are removed from status.storedVersions
+// so that they can be dropped from the CRD in a later release.
+package storageversion
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// listLimit is the page size used to list the objects of a CRD.
+const listLimit = 500
+
+// Result summarizes the migration of a CRD.
+type Result struct {
+	// CRD is the name of the migrated CRD.
+	CRD string
+	// StorageVersion is the version the objects are stored in after the migration.
+	StorageVersion string
+	// Migrated is the number of objects that have been rewritten, or would have been rewritten in dry-run mode.
+	Migrated int
+	// PrunedVersions are the versions that have been removed from status.storedVersions.
+	PrunedVersions []string
+}
+
+// Migrator rewrites all objects of a CRD in its storage version.
+type Migrator struct {
+	// Client is a client of the cluster the CRD is installed in.
+	Client client.Client
+	// DryRun only reports which objects would be migrated and which stored versions would be pruned.
+	DryRun bool
+}
+
+// Migrate rewrites all objects of the CRD with the given name in the storage version of the CRD
+// and prunes all other versions from status.storedVersions.
+// Nothing is done if the storage version is the only stored version.
+func (m *Migrator) Migrate(ctx context.Context, crdName string) (Result, error) {
+	l := logf.FromContext(ctx).WithName("storageversion").WithValues("crd", crdName, "dryRun", m.DryRun)
+	res := Result{CRD: crdName}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := m.Client.Get(ctx, client.ObjectKey{Name: crdName}, crd); err != nil {
+		return res, fmt.Errorf("unable to get CRD %s: %w", crdName, err)
+	}
+	res.StorageVersion = storageVersion(crd)
+	if res.StorageVersion == "" {
+		return res, fmt.Errorf("CRD %s has no storage version", crdName)
+	}
+	for _, v := range crd.Status.StoredVersions {
+		if v != res.StorageVersion {
+			res.PrunedVersions = append(res.PrunedVersions, v)
+		}
+	}
+	if len(res.PrunedVersions) == 0 {
+		l.V(1).Info("Objects are stored in the storage version only", "storageVersion", res.StorageVersion)
+		return res, nil
+	}
+
+	l.Info("Migrating objects to storage version", "storageVersion", res.StorageVersion, "storedVersions", crd.Status.StoredVersions)
+	migrated, err := m.migrateObjects(ctx, crd, res.StorageVersion)
+	res.Migrated = migrated
+	if err != nil {
+		return res, err
+	}
+
+	if m.DryRun {
+		l.Info("Dry run, stored versions are not pruned", "objects", res.Migrated, "prunedVersions", res.PrunedVersions)
+		return res, nil
+	}
+	patch := client.MergeFrom(crd.DeepCopy())
+	crd.Status.StoredVersions = []string{res.StorageVersion}
+	if err := m.Client.Status().Patch(ctx, crd, patch); err != nil {
+		return res, fmt.Errorf("unable to prune stored versions of CRD %s: %w", crdName, err)
+	}
+	l.Info("Migrated objects to storage version", "objects", res.Migrated, "prunedVersions", res.PrunedVersions)
+	return res, nil
+}
+
+// migrateObjects rewrites all objects of the CRD in the given version and returns the number of rewritten objects.
+func (m *Migrator) migrateObjects(ctx context.Context, crd *apiextensionsv1.CustomResourceDefinition, version string) (int, error) {
+	var migrated int
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{Group: crd.Spec.Group, Version: version, Kind: crd.Spec.Names.ListKind})
+	for {
+		if err := m.Client.List(ctx, list, client.Limit(listLimit), client.Continue(list.GetContinue())); err != nil {
+			return migrated, fmt.Errorf("unable to list objects of CRD %s: %w", crd.Name, err)
+		}
+		for i := range list.Items {
+			if err := m.rewrite(ctx, &list.Items[i]); err != nil {
+				return migrated, err
+			}
+			migrated++
+		}
+		if list.GetContinue() == "" {
+			return migrated, nil
+		}
+	}
+}
+
+// rewrite writes the object again without changes, which makes the API server store it in the current storage version.
+// The API server skips writes that do not change the stored bytes, so the empty merge patch is only persisted
+// because the object is encoded with a different apiVersion in the old storage version than in the new one.
+// Objects that are stored in the storage version already are not written again.
+func (m *Migrator) rewrite(ctx context.Context, obj *unstructured.Unstructured) error {
+	if m.DryRun {
+		logf.FromContext(ctx).WithName("storageversion").Info("Dry run, object would be migrated",
+			"kind", obj.GetKind(), "namespace", obj.GetNamespace(), "name", obj.GetName())
+		return nil
+	}
+	if err := client.IgnoreNotFound(m.Client.Patch(ctx, obj, client.RawPatch(types.MergePatchType, []byte("{}")))); err != nil {
+		return fmt.Errorf("unable to migrate %s %s: %w", obj.GetKind(), client.ObjectKeyFromObject(obj), err)
+	}
+	return nil
+}
+
+// storageVersion returns the name of the version of the CRD that is marked as storage version.
+func storageVersion(crd *apiextensionsv1.CustomResourceDefinition) string {
+	i := slices.IndexFunc(crd.Spec.Versions, func(v apiextensionsv1.CustomResourceDefinitionVersion) bool {
+		return v.Storage
+	})
+	if i < 0 {
+		return ""
+	}
+	return crd.Spec.Versions[i].Name
+}
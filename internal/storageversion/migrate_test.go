@@ -0,0 +1,216 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storageversion
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// testPageSize is the number of objects the test client returns per page, the fake client does not page on its own.
+const testPageSize = 2
+
+// errListFailed is returned by the test client for the page that fails.
+var errListFailed = errors.New("list failed")
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name           string
+		storedVersions []string
+		dryRun         bool
+		// failPage is the index of the page that cannot be listed, -1 if all pages succeed.
+		failPage     int
+		wantErr      bool
+		wantMigrated int
+		wantPatched  int
+		wantPages    int
+		wantStored   []string
+		wantPruned   []string
+	}{
+		{
+			name:           "all pages are migrated before the stored versions are pruned",
+			storedVersions: []string{"v1beta1", "v1alpha1"},
+			failPage:       -1,
+			wantMigrated:   5,
+			wantPatched:    5,
+			wantPages:      3,
+			wantStored:     []string{"v1alpha1"},
+			wantPruned:     []string{"v1beta1"},
+		},
+		{
+			name:           "stored versions are kept if a page fails",
+			storedVersions: []string{"v1beta1", "v1alpha1"},
+			failPage:       1,
+			wantErr:        true,
+			wantMigrated:   2,
+			wantPatched:    2,
+			wantPages:      1,
+			wantStored:     []string{"v1beta1", "v1alpha1"},
+			wantPruned:     []string{"v1beta1"},
+		},
+		{
+			name:           "dry run neither writes objects nor prunes stored versions",
+			storedVersions: []string{"v1beta1", "v1alpha1"},
+			dryRun:         true,
+			failPage:       -1,
+			wantMigrated:   5,
+			wantPages:      3,
+			wantStored:     []string{"v1beta1", "v1alpha1"},
+			wantPruned:     []string{"v1beta1"},
+		},
+		{
+			name:           "nothing is migrated if only the storage version is stored",
+			storedVersions: []string{"v1alpha1"},
+			failPage:       -1,
+			wantStored:     []string{"v1alpha1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			var pages, patched int
+			c := newTestClient(t, tt.storedVersions, interceptor.Funcs{
+				List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					o := (&client.ListOptions{}).ApplyOptions(opts)
+					if o.Limit != listLimit {
+						t.Errorf("list limit = %d, want %d", o.Limit, listLimit)
+					}
+					page := 0
+					if o.Continue != "" {
+						page, _ = strconv.Atoi(o.Continue)
+					}
+					if page == tt.failPage {
+						return errListFailed
+					}
+					return listPage(ctx, c, list, page, &pages)
+				},
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					if _, ok := obj.(*unstructured.Unstructured); ok {
+						patched++
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			})
+
+			m := &Migrator{Client: c, DryRun: tt.dryRun}
+			res, err := m.Migrate(ctx, "widgets.example.com")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Migrate() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, errListFailed) {
+				t.Errorf("Migrate() error = %v, want %v", err, errListFailed)
+			}
+			if res.StorageVersion != "v1alpha1" {
+				t.Errorf("storage version = %s, want v1alpha1", res.StorageVersion)
+			}
+			if res.Migrated != tt.wantMigrated {
+				t.Errorf("migrated = %d, want %d", res.Migrated, tt.wantMigrated)
+			}
+			if !slices.Equal(res.PrunedVersions, tt.wantPruned) {
+				t.Errorf("pruned versions = %v, want %v", res.PrunedVersions, tt.wantPruned)
+			}
+			if patched != tt.wantPatched {
+				t.Errorf("patched objects = %d, want %d", patched, tt.wantPatched)
+			}
+			if pages != tt.wantPages {
+				t.Errorf("listed pages = %d, want %d", pages, tt.wantPages)
+			}
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			if err := c.Get(ctx, client.ObjectKey{Name: "widgets.example.com"}, crd); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(crd.Status.StoredVersions, tt.wantStored) {
+				t.Errorf("stored versions = %v, want %v", crd.Status.StoredVersions, tt.wantStored)
+			}
+		})
+	}
+}
+
+// newTestClient returns a client with a CRD with the given stored versions and five of its objects.
+func newTestClient(t *testing.T, storedVersions []string, funcs interceptor.Funcs) client.Client {
+	t.Helper()
+	s := runtime.NewScheme()
+	if err := apiextensionsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	gv := schema.GroupVersion{Group: "example.com", Version: "v1alpha1"}
+	s.AddKnownTypeWithName(gv.WithKind("Widget"), &unstructured.Unstructured{})
+	s.AddKnownTypeWithName(gv.WithKind("WidgetList"), &unstructured.UnstructuredList{})
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "Widget", ListKind: "WidgetList", Plural: "widgets"},
+			Scope: apiextensionsv1.NamespaceScoped,
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: true, Storage: true},
+				{Name: "v1beta1", Served: true},
+			},
+		},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: storedVersions},
+	}
+	objs := []client.Object{crd}
+	for i := range 5 {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gv.WithKind("Widget"))
+		obj.SetNamespace("default")
+		obj.SetName("widget-" + strconv.Itoa(i))
+		objs = append(objs, obj)
+	}
+	return fake.NewClientBuilder().
+		WithScheme(s).
+		WithObjects(objs...).
+		WithStatusSubresource(crd).
+		WithInterceptorFuncs(funcs).
+		Build()
+}
+
+// listPage lists all objects and only keeps the objects of the given page.
+// Like the API server, it returns a continue token unless the page is the last one.
+func listPage(ctx context.Context, c client.WithWatch, list client.ObjectList, page int, pages *int) error {
+	if err := c.List(ctx, list); err != nil {
+		return err
+	}
+	ul := list.(*unstructured.UnstructuredList)
+	slices.SortFunc(ul.Items, func(a, b unstructured.Unstructured) int {
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	total := len(ul.Items)
+	start := min(page*testPageSize, total)
+	end := min(start+testPageSize, total)
+	ul.Items = ul.Items[start:end]
+	ul.SetContinue("")
+	if end < total {
+		ul.SetContinue(strconv.Itoa(page + 1))
+	}
+	*pages++
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openmcp-project/controller-utils/pkg/clusters"
+	"github.com/openmcp-project/openmcp-operator/lib/clusteraccess/advanced"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ClusterAccessReconciler adds spans for the cluster access resolution of the wrapped reconciler.
+// It must wrap the fully configured reconciler, because the builder methods return the wrapped reconciler.
+type ClusterAccessReconciler struct {
+	advanced.ClusterAccessReconciler
+}
+
+var _ advanced.ClusterAccessReconciler = &ClusterAccessReconciler{}
+
+// Reconcile traces the creation and update of the cluster and access requests for a service object.
+func (r *ClusterAccessReconciler) Reconcile(ctx context.Context, request reconcile.Request, additionalData ...any) (reconcile.Result, error) {
+	ctx, span := startRequest(ctx, "ClusterAccess.Reconcile", request)
+	res, err := r.ClusterAccessReconciler.Reconcile(ctx, request, additionalData...)
+	End(span, err)
+	return res, err
+}
+
+// ReconcileDelete traces the deletion of the cluster and access requests for a service object.
+func (r *ClusterAccessReconciler) ReconcileDelete(ctx context.Context, request reconcile.Request, additionalData ...any) (reconcile.Result, error) {
+	ctx, span := startRequest(ctx, "ClusterAccess.ReconcileDelete", request)
+	res, err := r.ClusterAccessReconciler.ReconcileDelete(ctx, request, additionalData...)
+	End(span, err)
+	return res, err
+}
+
+// Access traces the resolution of the access to a cluster of a service object.
+// The client of the returned cluster adds a span for every request.
+func (r *ClusterAccessReconciler) Access(ctx context.Context, request reconcile.Request, id string, additionalData ...any) (*clusters.Cluster, error) {
+	ctx, span := startRequest(ctx, "ClusterAccess.Access", request, attribute.String("cluster.id", id))
+	cluster, err := r.ClusterAccessReconciler.Access(ctx, request, id, additionalData...)
+	if err == nil {
+		err = traceClient(cluster)
+	}
+	End(span, err)
+	if err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+// traceClient recreates the client of the cluster with a traced transport.
+// The reconciler creates a new cluster on every access, so it is safe to replace its client.
+// Clusters without a REST config are left unchanged.
+func traceClient(cluster *clusters.Cluster) error {
+	if cluster == nil || !cluster.HasRESTConfig() {
+		return nil
+	}
+	cfg := rest.CopyConfig(cluster.RESTConfig())
+	WrapRESTConfig(cfg)
+	if err := cluster.WithRESTConfig(cfg).InitializeClient(cluster.Scheme()); err != nil {
+		return fmt.Errorf("unable to trace client of cluster '%s': %w", cluster.ID(), err)
+	}
+	return nil
+}
+
+func startRequest(ctx context.Context, name string, request reconcile.Request, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		attribute.String("k8s.namespace.name", request.Namespace),
+		attribute.String("k8s.object.name", request.Name),
+	)
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
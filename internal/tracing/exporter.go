@@ -0,0 +1,92 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// WriterExporter writes spans as JSON lines, one line per span, e.g. to stdout or a file for local testing.
+type WriterExporter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	w   io.Writer
+}
+
+// NewWriterExporter returns an exporter that writes to w. If w is an io.Closer, it is closed on shutdown.
+func NewWriterExporter(w io.Writer) *WriterExporter {
+	return &WriterExporter{enc: json.NewEncoder(w), w: w}
+}
+
+// spanRecord is the JSON representation of a span.
+type spanRecord struct {
+	Name         string            `json:"name"`
+	TraceID      string            `json:"traceID"`
+	SpanID       string            `json:"spanID"`
+	ParentSpanID string            `json:"parentSpanID,omitempty"`
+	Start        time.Time         `json:"start"`
+	Duration     string            `json:"duration"`
+	Status       string            `json:"status"`
+	Error        string            `json:"error,omitempty"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+}
+
+// ExportSpans implements sdktrace.SpanExporter.
+func (e *WriterExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, s := range spans {
+		rec := spanRecord{
+			Name:     s.Name(),
+			TraceID:  s.SpanContext().TraceID().String(),
+			SpanID:   s.SpanContext().SpanID().String(),
+			Start:    s.StartTime(),
+			Duration: s.EndTime().Sub(s.StartTime()).String(),
+			Status:   s.Status().Code.String(),
+			Error:    s.Status().Description,
+		}
+		if s.Parent().IsValid() {
+			rec.ParentSpanID = s.Parent().SpanID().String()
+		}
+		if attrs := s.Attributes(); len(attrs) > 0 {
+			rec.Attributes = make(map[string]string, len(attrs))
+			for _, kv := range attrs {
+				rec.Attributes[string(kv.Key)] = kv.Value.Emit()
+			}
+		}
+		if err := e.enc.Encode(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown implements sdktrace.SpanExporter.
+func (e *WriterExporter) Shutdown(_ context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if c, ok := e.w.(io.Closer); ok && c != os.Stdout {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+//go:generate opencontrolplane-gen
+// Package tracing sets up OpenTelemetry tracing for the service provider.
+// Spans are created for the reconciles of service objects, the cluster access resolution
+// and every API call against MCP and workload clusters, and exported via OTLP or written to stdout or a file.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+const instrumentationName = "github.com/openmcp-project/service-provider-template"
+
+const (
+	// ExporterNone disables tracing.
+	ExporterNone = "none"
+	// ExporterOTLP exports spans via OTLP over gRPC.
+	ExporterOTLP = "otlp"
+	// ExporterStdout writes spans as JSON lines to stdout.
+	ExporterStdout = "stdout"
+	// ExporterFile writes spans as JSON lines to a file.
+	ExporterFile = "file"
+)
+
+// Options configure tracing.
+type Options struct {
+	// Exporter is one of none, otlp, stdout or file. Tracing is disabled if it is empty.
+	Exporter string
+	// OTLPEndpoint is the host and port of the OTLP gRPC receiver.
+	// If empty, the OTEL_EXPORTER_OTLP_ENDPOINT environment variable or the default of the exporter is used.
+	OTLPEndpoint string
+	// OTLPInsecure disables TLS for the connection to the OTLP receiver.
+	OTLPInsecure bool
+	// File is the path of the file spans are written to by the file exporter.
+	File string
+	// SampleRatio is the ratio of traces that are sampled, between 0 and 1.
+	SampleRatio float64
+	// ServiceName is reported as service.name resource attribute.
+	ServiceName string
+}
+
+// Enabled returns whether spans are exported.
+func (o Options) Enabled() bool {
+	return o.Exporter != "" && o.Exporter != ExporterNone
+}
+
+// Provider flushes and stops the exporter of the global tracer provider when the manager stops.
+type Provider struct {
+	tp *sdktrace.TracerProvider
+}
+
+// Setup creates the exporter and installs a global tracer provider and propagator.
+// The returned provider must be added to the manager to flush the remaining spans on shutdown.
+func Setup(ctx context.Context, opts Options) (*Provider, error) {
+	exporter, err := newExporter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	res := resource.NewSchemaless(attribute.String("service.name", opts.ServiceName))
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return &Provider{tp: tp}, nil
+}
+
+func newExporter(ctx context.Context, opts Options) (sdktrace.SpanExporter, error) {
+	switch opts.Exporter {
+	case ExporterOTLP:
+		var grpcOpts []otlptracegrpc.Option
+		if opts.OTLPEndpoint != "" {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithEndpoint(opts.OTLPEndpoint))
+		}
+		if opts.OTLPInsecure {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, grpcOpts...)
+	case ExporterStdout:
+		return NewWriterExporter(os.Stdout), nil
+	case ExporterFile:
+		if opts.File == "" {
+			return nil, errors.New("a file is required for the file exporter")
+		}
+		f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open trace file: %w", err)
+		}
+		return NewWriterExporter(f), nil
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q, must be one of %s, %s, %s or %s", opts.Exporter, ExporterNone, ExporterOTLP, ExporterStdout, ExporterFile)
+	}
+}
+
+// Start blocks until the context is canceled and shuts down the tracer provider afterwards,
+// which exports the remaining spans. It implements manager.Runnable.
+func (p *Provider) Start(ctx context.Context) error {
+	<-ctx.Done()
+	return p.tp.Shutdown(context.WithoutCancel(ctx))
+}
+
+// NeedLeaderElection returns false, spans are exported by all replicas.
+func (p *Provider) NeedLeaderElection() bool {
+	return false
+}
+
+// Tracer returns the tracer of the service provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// StartObject starts a span for an operation on a Kubernetes object.
+func StartObject(ctx context.Context, name string, obj client.Object) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(
+		attribute.String("k8s.namespace.name", obj.GetNamespace()),
+		attribute.String("k8s.object.name", obj.GetName()),
+	))
+}
+
+// End records err on the span, if set, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// WrapRESTConfig adds a span for every request made with clients that are created from cfg.
+func WrapRESTConfig(cfg *rest.Config) {
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return otelhttp.NewTransport(rt, otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + r.URL.Path
+		}))
+	})
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// useTestProvider installs a global tracer provider that writes spans synchronously to the returned buffer.
+func useTestProvider(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(NewWriterExporter(buf)))
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return buf
+}
+
+func decodeSpans(t *testing.T, buf *bytes.Buffer) []spanRecord {
+	t.Helper()
+	var spans []spanRecord
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var rec spanRecord
+		if err := dec.Decode(&rec); err != nil {
+			t.Fatal(err)
+		}
+		spans = append(spans, rec)
+	}
+	return spans
+}
+
+func TestStartObjectAndEnd(t *testing.T) {
+	buf := useTestProvider(t)
+	obj := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default"}}
+
+	ctx, parent := StartObject(context.Background(), "Reconcile", obj)
+	_, child := Tracer().Start(ctx, "Apply")
+	End(child, errors.New("apply failed"))
+	End(parent, nil)
+
+	spans := decodeSpans(t, buf)
+	if len(spans) != 2 {
+		t.Fatalf("exported %d spans, want 2", len(spans))
+	}
+	apply, reconcile := spans[0], spans[1]
+	if apply.ParentSpanID != reconcile.SpanID || apply.TraceID != reconcile.TraceID {
+		t.Errorf("span %s is not a child of span %s", apply.Name, reconcile.Name)
+	}
+	if apply.Status != "Error" || apply.Error != "apply failed" {
+		t.Errorf("failed span status = %s %q, want Error \"apply failed\"", apply.Status, apply.Error)
+	}
+	if reconcile.Status == "Error" {
+		t.Errorf("successful span status = %s", reconcile.Status)
+	}
+	if reconcile.Attributes["k8s.namespace.name"] != "default" || reconcile.Attributes["k8s.object.name"] != "foo" {
+		t.Errorf("attributes = %v, want the namespace and name of the object", reconcile.Attributes)
+	}
+}
+
+func TestWrapRESTConfig(t *testing.T) {
+	buf := useTestProvider(t)
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	cfg := &rest.Config{Host: srv.URL}
+	WrapRESTConfig(cfg)
+	httpClient, err := rest.HTTPClientFor(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/api/v1/namespaces", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+
+	if traceparent == "" {
+		t.Error("trace context has not been propagated to the API server")
+	}
+	spans := decodeSpans(t, buf)
+	if len(spans) != 1 || spans[0].Name != "GET /api/v1/namespaces" {
+		t.Errorf("spans = %+v, want a single span for the request", spans)
+	}
+}
+
+func TestNewExporter(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "spans.jsonl")
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{name: "stdout", opts: Options{Exporter: ExporterStdout}},
+		{name: "file", opts: Options{Exporter: ExporterFile, File: file}},
+		{name: "file without path", opts: Options{Exporter: ExporterFile}, wantErr: true},
+		{name: "unknown exporter", opts: Options{Exporter: "jaeger"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter, err := newExporter(context.Background(), tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newExporter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if err := exporter.Shutdown(context.Background()); err != nil {
+					t.Errorf("Shutdown() error = %v", err)
+				}
+			}
+		})
+	}
+	if _, err := os.Stat(file); err != nil {
+		t.Errorf("trace file has not been created: %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package selfsigned bootstraps the webhook server without cert-manager.
+// It generates a serving certificate signed by a self-signed CA and registers the webhook configurations and the conversion webhooks
+// of the CRDs with the matching CA bundle.
+package selfsigned
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// caValidity is the validity of the generated CA certificate.
+	caValidity = 10 * 365 * 24 * time.Hour
+	// certValidity is the validity of the generated serving certificate.
+	// A new certificate is generated on every start of the service provider.
+	certValidity = 365 * 24 * time.Hour
+)
+
+// ServiceDNSNames returns the DNS names under which a service is reachable from within the cluster.
+func ServiceDNSNames(name, namespace string) []string {
+	return []string{
+		name,
+		fmt.Sprintf("%s.%s", name, namespace),
+		fmt.Sprintf("%s.%s.svc", name, namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", name, namespace),
+	}
+}
+
+// CA is the certificate authority that signs the serving certificates of the webhook server.
+type CA struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// NewCA generates a CA with a common name derived from name.
+func NewCA(name string) (*CA, error) {
+	now := time.Now()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate CA key: %w", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          mustSerialNumber(),
+		Subject:               pkix.Name{CommonName: name + "-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create CA certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal CA key: %w", err)
+	}
+	return parseCA(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+// LoadOrCreateCA returns the CA stored in the secret with the given key. The CA is generated and stored in the secret
+// if the secret does not exist yet. All replicas of the service provider share the CA, so the CA bundle registered
+// by any replica trusts the serving certificates of all replicas.
+func LoadOrCreateCA(ctx context.Context, c client.Client, key client.ObjectKey) (*CA, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, key, secret)
+	if apierrors.IsNotFound(err) {
+		ca, err := NewCA(key.Name)
+		if err != nil {
+			return nil, err
+		}
+		secret = ca.secret(key)
+		if err = c.Create(ctx, secret); err == nil {
+			return ca, nil
+		}
+		if !apierrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("unable to create CA secret: %w", err)
+		}
+		// another replica has created the CA in the meantime
+		err = c.Get(ctx, key, secret)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get CA secret: %w", err)
+	}
+	return parseCA(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+}
+
+// parseCA parses the PEM encoded certificate and EC private key of a CA.
+func parseCA(certPEM, keyPEM []byte) (*CA, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil {
+		return nil, fmt.Errorf("CA certificate is not PEM encoded")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse CA certificate: %w", err)
+	}
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, fmt.Errorf("CA key is not PEM encoded")
+	}
+	key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse CA key: %w", err)
+	}
+	return &CA{cert: cert, key: key, certPEM: certPEM, keyPEM: keyPEM}, nil
+}
+
+// secret returns the secret the CA is stored in.
+func (ca *CA) secret(key client.ObjectKey) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       ca.certPEM,
+			corev1.TLSPrivateKeyKey: ca.keyPEM,
+		},
+	}
+}
+
+// Bundle returns the PEM encoded CA certificate to be used as CA bundle of the webhook configurations.
+func (ca *CA) Bundle() []byte {
+	return ca.certPEM
+}
+
+// WriteServingCertificate generates a serving certificate signed by the CA for the given DNS names.
+// The certificate and its key are written to dir as certName and keyName.
+func (ca *CA) WriteServingCertificate(dir, certName, keyName string, dnsNames ...string) error {
+	if len(dnsNames) == 0 {
+		return fmt.Errorf("at least one DNS name is required")
+	}
+	now := time.Now()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("unable to generate serving key: %w", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: mustSerialNumber(),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(certValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return fmt.Errorf("unable to create serving certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("unable to marshal serving key: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("unable to create certificate directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, certName), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0o600); err != nil {
+		return fmt.Errorf("unable to write serving certificate: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, keyName), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		return fmt.Errorf("unable to write serving key: %w", err)
+	}
+	return nil
+}
+
+func mustSerialNumber() *big.Int {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		panic(fmt.Sprintf("unable to generate serial number: %v", err))
+	}
+	return serial
+}
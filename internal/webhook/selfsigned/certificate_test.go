@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selfsigned
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLoadOrCreateCA(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+	key := client.ObjectKey{Namespace: "default", Name: "webhook-ca"}
+
+	// every replica loads the CA, only the first one generates it
+	first, err := LoadOrCreateCA(ctx, c, key)
+	if err != nil {
+		t.Fatalf("LoadOrCreateCA() error = %v", err)
+	}
+	second, err := LoadOrCreateCA(ctx, c, key)
+	if err != nil {
+		t.Fatalf("LoadOrCreateCA() error = %v", err)
+	}
+	if !bytes.Equal(first.Bundle(), second.Bundle()) {
+		t.Fatalf("replicas use different CA bundles")
+	}
+
+	// the serving certificate of every replica is trusted by the shared bundle
+	for i, ca := range []*CA{first, second} {
+		dir := t.TempDir()
+		if err := ca.WriteServingCertificate(dir, "tls.crt", "tls.key", "webhook.default.svc"); err != nil {
+			t.Fatalf("WriteServingCertificate() error = %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "tls.crt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		block, _ := pem.Decode(data)
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots := x509.NewCertPool()
+		roots.AppendCertsFromPEM(first.Bundle())
+		if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, DNSName: "webhook.default.svc"}); err != nil {
+			t.Errorf("serving certificate of replica %d is not trusted: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selfsigned
+
+import (
+	"context"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EnsureConversionWebhook configures the CRD to convert between its versions via the webhook served at path.
+// The versions are served as in want, the CRD from the manifests of the service provider, since the init command
+// only serves the storage version as long as no conversion webhook is configured.
+func EnsureConversionWebhook(ctx context.Context, c client.Client, want *apiextensionsv1.CustomResourceDefinition, path string, clientConfig ClientConfigFunc) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := c.Get(ctx, client.ObjectKey{Name: want.Name}, crd); err != nil {
+		return err
+	}
+	cfg := clientConfig(path)
+	conversionClientConfig := &apiextensionsv1.WebhookClientConfig{
+		URL:      cfg.URL,
+		CABundle: cfg.CABundle,
+	}
+	if cfg.Service != nil {
+		conversionClientConfig.Service = &apiextensionsv1.ServiceReference{
+			Namespace: cfg.Service.Namespace,
+			Name:      cfg.Service.Name,
+			Path:      cfg.Service.Path,
+			Port:      cfg.Service.Port,
+		}
+	}
+	patch := client.MergeFrom(crd.DeepCopy())
+	crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.WebhookConverter,
+		Webhook: &apiextensionsv1.WebhookConversion{
+			ClientConfig:             conversionClientConfig,
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+	for i := range crd.Spec.Versions {
+		for _, wv := range want.Spec.Versions {
+			if wv.Name == crd.Spec.Versions[i].Name {
+				crd.Spec.Versions[i].Served = wv.Served
+			}
+		}
+	}
+	return c.Patch(ctx, crd, patch)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selfsigned
+
+import (
+	"context"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureConversionWebhook(t *testing.T) {
+	ctx := context.Background()
+	s := runtime.NewScheme()
+	if err := apiextensionsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	want := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "tests.example.com"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: true, Storage: true},
+				{Name: "v1beta1", Served: true},
+			},
+		},
+	}
+	// the init command only serves the storage version without a conversion webhook
+	existing := want.DeepCopy()
+	existing.Spec.Versions[1].Served = false
+	existing.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{Strategy: apiextensionsv1.NoneConverter}
+	c := fake.NewClientBuilder().WithScheme(s).WithObjects(existing).Build()
+
+	caBundle := []byte("ca")
+	if err := EnsureConversionWebhook(ctx, c, want, "/convert", URLClientConfig("https://webhook.example.com/", caBundle)); err != nil {
+		t.Fatalf("EnsureConversionWebhook() error = %v", err)
+	}
+
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(want), crd); err != nil {
+		t.Fatal(err)
+	}
+	conversion := crd.Spec.Conversion
+	if conversion == nil || conversion.Strategy != apiextensionsv1.WebhookConverter || conversion.Webhook == nil {
+		t.Fatalf("conversion = %+v, want webhook conversion", conversion)
+	}
+	if url := conversion.Webhook.ClientConfig.URL; url == nil || *url != "https://webhook.example.com/convert" {
+		t.Errorf("conversion webhook URL = %v, want https://webhook.example.com/convert", url)
+	}
+	if string(conversion.Webhook.ClientConfig.CABundle) != string(caBundle) {
+		t.Errorf("conversion webhook CA bundle = %q, want %q", conversion.Webhook.ClientConfig.CABundle, caBundle)
+	}
+	for i, v := range crd.Spec.Versions {
+		if v.Served != want.Spec.Versions[i].Served || v.Storage != want.Spec.Versions[i].Storage {
+			t.Errorf("version %s served = %t, storage = %t, want %t, %t", v.Name, v.Served, v.Storage, want.Spec.Versions[i].Served, want.Spec.Versions[i].Storage)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selfsigned
+
+import (
+	"context"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Webhook describes a webhook that is served by the webhook server of the service provider.
+type Webhook struct {
+	// Name is the fully qualified name of the webhook, e.g. vfoo-v1alpha1.kb.io.
+	Name string
+	// Path is the path under which the webhook is served.
+	Path string
+	// Rules select the requests that are sent to the webhook.
+	Rules []admissionregistrationv1.RuleWithOperations
+}
+
+// ClientConfigFunc returns the client config that the API server uses to call the webhook served at path.
+type ClientConfigFunc func(path string) admissionregistrationv1.WebhookClientConfig
+
+// ServiceClientConfig calls the webhooks via a service in the cluster the configuration is registered in.
+func ServiceClientConfig(namespace, name string, port int32, caBundle []byte) ClientConfigFunc {
+	return func(path string) admissionregistrationv1.WebhookClientConfig {
+		return admissionregistrationv1.WebhookClientConfig{
+			Service: &admissionregistrationv1.ServiceReference{
+				Namespace: namespace,
+				Name:      name,
+				Path:      ptr.To(path),
+				Port:      ptr.To(port),
+			},
+			CABundle: caBundle,
+		}
+	}
+}
+
+// URLClientConfig calls the webhooks via an URL, e.g. for clusters that cannot reach the service of the webhook server.
+func URLClientConfig(baseURL string, caBundle []byte) ClientConfigFunc {
+	return func(path string) admissionregistrationv1.WebhookClientConfig {
+		return admissionregistrationv1.WebhookClientConfig{
+			URL:      ptr.To(strings.TrimSuffix(baseURL, "/") + path),
+			CABundle: caBundle,
+		}
+	}
+}
+
+// EnsureValidatingWebhookConfiguration creates or updates the ValidatingWebhookConfiguration with the given name.
+// Webhooks that are not part of the list are removed from the configuration.
+func EnsureValidatingWebhookConfiguration(ctx context.Context, c client.Client, name string, clientConfig ClientConfigFunc, webhooks ...Webhook) error {
+	cfg := &admissionregistrationv1.ValidatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	_, err := ctrl.CreateOrUpdate(ctx, c, cfg, func() error {
+		cfg.Webhooks = make([]admissionregistrationv1.ValidatingWebhook, 0, len(webhooks))
+		for _, wh := range webhooks {
+			cfg.Webhooks = append(cfg.Webhooks, admissionregistrationv1.ValidatingWebhook{
+				Name:                    wh.Name,
+				ClientConfig:            clientConfig(wh.Path),
+				Rules:                   wh.Rules,
+				FailurePolicy:           ptr.To(admissionregistrationv1.Fail),
+				SideEffects:             ptr.To(admissionregistrationv1.SideEffectClassNone),
+				AdmissionReviewVersions: []string{"v1"},
+			})
+		}
+		return nil
+	})
+	return err
+}
+
+// EnsureMutatingWebhookConfiguration creates or updates the MutatingWebhookConfiguration with the given name.
+// Webhooks that are not part of the list are removed from the configuration.
+func EnsureMutatingWebhookConfiguration(ctx context.Context, c client.Client, name string, clientConfig ClientConfigFunc, webhooks ...Webhook) error {
+	cfg := &admissionregistrationv1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	_, err := ctrl.CreateOrUpdate(ctx, c, cfg, func() error {
+		cfg.Webhooks = make([]admissionregistrationv1.MutatingWebhook, 0, len(webhooks))
+		for _, wh := range webhooks {
+			cfg.Webhooks = append(cfg.Webhooks, admissionregistrationv1.MutatingWebhook{
+				Name:                    wh.Name,
+				ClientConfig:            clientConfig(wh.Path),
+				Rules:                   wh.Rules,
+				FailurePolicy:           ptr.To(admissionregistrationv1.Fail),
+				SideEffects:             ptr.To(admissionregistrationv1.SideEffectClassNone),
+				AdmissionReviewVersions: []string{"v1"},
+			})
+		}
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,40 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package v1alpha1
+
+import (
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// ConversionPath is the path under which controller-runtime serves the conversion webhook.
+// It is registered together with the admission webhooks of the hub types and converts all versions of the API.
+const ConversionPath = "/convert"
+
+// opencontrolplane-gen:replace Foo=KIND
+// FooCRDName returns the name of the CRD of Foo that is converted by the conversion webhook.
+// opencontrolplane-gen:replace Foo=KIND
+func FooCRDName() string {
+	// opencontrolplane-gen:replace foo=KIND_LOWER
+	return "foos." + apiv1alpha1.GroupVersion.Group
+}
+
+// ProviderConfigCRDName returns the name of the CRD of ProviderConfig that is converted by the conversion webhook.
+func ProviderConfigCRDName() string {
+	return "providerconfigs." + apiv1alpha1.GroupVersion.Group
+}
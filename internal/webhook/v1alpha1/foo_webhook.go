@@ -0,0 +1,207 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/domainservice"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/webhook/selfsigned"
+)
+
+// opencontrolplane-gen:replace Foo=KIND
+// SetupFooWebhookWithManager registers the webhooks for Foo in the manager.
+// opencontrolplane-gen:replace Foo=KIND
+func SetupFooWebhookWithManager(mgr ctrl.Manager) error {
+	// opencontrolplane-gen:replace Foo=KIND
+	return ctrl.NewWebhookManagedBy(mgr, &apiv1alpha1.Foo{}).
+		// opencontrolplane-gen:replace Foo=KIND
+		// opencontrolplane-gen:replace Foo=KIND
+		WithDefaulter(&FooCustomDefaulter{}).
+		// opencontrolplane-gen:replace Foo=KIND
+		WithValidator(&FooCustomValidator{}).
+		Complete()
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// FooValidatingWebhook describes the validating webhook for Foo for the self-signed bootstrap mode.
+// opencontrolplane-gen:replace Foo=KIND
+func FooValidatingWebhook() selfsigned.Webhook {
+	return selfsigned.Webhook{
+		// opencontrolplane-gen:replace foo=KIND_LOWER
+		Name: "vfoo-v1alpha1.kb.io",
+		// opencontrolplane-gen:replace Foo=KIND
+		Path: validatePath("Foo"),
+		// opencontrolplane-gen:replace foo=KIND_LOWER
+		Rules: rules("foos", admissionregistrationv1.Create, admissionregistrationv1.Update),
+	}
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// FooMutatingWebhook describes the defaulting webhook for Foo for the self-signed bootstrap mode.
+// opencontrolplane-gen:replace Foo=KIND
+func FooMutatingWebhook() selfsigned.Webhook {
+	return selfsigned.Webhook{
+		// opencontrolplane-gen:replace foo=KIND_LOWER
+		Name: "mfoo-v1alpha1.kb.io",
+		// opencontrolplane-gen:replace Foo=KIND
+		Path: mutatePath("Foo"),
+		// opencontrolplane-gen:replace foo=KIND_LOWER
+		Rules: rules("foos", admissionregistrationv1.Create, admissionregistrationv1.Update),
+	}
+}
+
+// opencontrolplane-gen:replace foo=KIND_LOWER
+// +kubebuilder:webhook:path=/mutate-foo-services-open-control-plane-io-v1alpha1-foo,mutating=true,failurePolicy=fail,sideEffects=None,groups=foo.services.open-control-plane.io,resources=foos,verbs=create;update,versions=v1alpha1,name=mfoo-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// opencontrolplane-gen:replace Foo=KIND
+// FooCustomDefaulter sets default values on Foo resources when they are created or updated.
+// opencontrolplane-gen:replace Foo=KIND
+type FooCustomDefaulter struct{}
+
+// opencontrolplane-gen:replace Foo=KIND
+var _ admission.Defaulter[*apiv1alpha1.Foo] = &FooCustomDefaulter{}
+
+// Default sets the deletion policy if it is not specified.
+// The grace period of the ForceDelete deletion policy is resolved by the controller, so that a later change of the default applies to existing objects.
+// opencontrolplane-gen:replace Foo=KIND
+func (d *FooCustomDefaulter) Default(_ context.Context, obj *apiv1alpha1.Foo) error {
+	if obj.Spec.DeletionPolicy == "" {
+		obj.Spec.DeletionPolicy = apiv1alpha1.DeletionPolicyDelete
+	}
+	return nil
+}
+
+// opencontrolplane-gen:replace foo=KIND_LOWER
+// +kubebuilder:webhook:path=/validate-foo-services-open-control-plane-io-v1alpha1-foo,mutating=false,failurePolicy=fail,sideEffects=None,groups=foo.services.open-control-plane.io,resources=foos,verbs=create;update,versions=v1alpha1,name=vfoo-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// opencontrolplane-gen:replace Foo=KIND
+// FooCustomValidator validates Foo resources when they are created or updated.
+// opencontrolplane-gen:replace Foo=KIND
+type FooCustomValidator struct{}
+
+// opencontrolplane-gen:replace Foo=KIND
+var _ admission.Validator[*apiv1alpha1.Foo] = &FooCustomValidator{}
+
+// ValidateCreate validates the spec of a new object.
+// opencontrolplane-gen:replace Foo=KIND
+func (v *FooCustomValidator) ValidateCreate(_ context.Context, obj *apiv1alpha1.Foo) (admission.Warnings, error) {
+	return nil, invalid(obj, validateFooSpec(nil, obj))
+}
+
+// ValidateUpdate validates the changed fields of the updated object and rejects changes of immutable fields.
+// Objects in deletion and updates that do not change the spec are always allowed, so that finalizers and the status
+// of objects can be updated even if they were created before a validation rule was introduced.
+// opencontrolplane-gen:replace Foo=KIND
+func (v *FooCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj *apiv1alpha1.Foo) (admission.Warnings, error) {
+	if newObj.DeletionTimestamp != nil || equality.Semantic.DeepEqual(oldObj.Spec, newObj.Spec) {
+		return nil, nil
+	}
+	errs := validateFooSpec(oldObj, newObj)
+	errs = append(errs, validateFooUpdate(oldObj, newObj)...)
+	return nil, invalid(newObj, errs)
+}
+
+// ValidateDelete allows the deletion of all objects, the removal of managed resources is handled by the controller.
+// opencontrolplane-gen:replace Foo=KIND
+func (v *FooCustomValidator) ValidateDelete(context.Context, *apiv1alpha1.Foo) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// validateFooSpec checks the rules for the spec of Foo that cannot be expressed in the OpenAPI schema.
+// On updates, oldObj is the object before the update and only the fields that changed are validated.
+// opencontrolplane-gen:replace Foo=KIND
+func validateFooSpec(oldObj, obj *apiv1alpha1.Foo) field.ErrorList {
+	specPath := field.NewPath("spec")
+	// opencontrolplane-gen:replace Foo=KIND
+	var oldSpec *apiv1alpha1.FooSpec
+	if oldObj != nil {
+		oldSpec = &oldObj.Spec
+	}
+	// opencontrolplane-gen:replace Foo=KIND
+	errs := validateFooField(oldSpec, obj, specPath)
+	return append(errs, validateForceDeleteGracePeriod(oldSpec, obj, specPath)...)
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// validateFooField rejects an empty foo field, unless it was already empty before the update.
+// opencontrolplane-gen:replace Foo=KIND
+func validateFooField(oldSpec *apiv1alpha1.FooSpec, obj *apiv1alpha1.Foo, specPath *field.Path) field.ErrorList {
+	// opencontrolplane-gen:replace Foo=KIND
+	if obj.Spec.Foo == nil || *obj.Spec.Foo != "" {
+		return nil
+	}
+	// opencontrolplane-gen:replace Foo=KIND
+	if oldSpec != nil && equality.Semantic.DeepEqual(oldSpec.Foo, obj.Spec.Foo) {
+		return nil
+	}
+	return field.ErrorList{field.Required(specPath.Child("foo"), "must not be empty if set")}
+}
+
+// validateForceDeleteGracePeriod checks the grace period if it or the deletion policy it belongs to changed.
+// opencontrolplane-gen:replace Foo=KIND
+func validateForceDeleteGracePeriod(oldSpec *apiv1alpha1.FooSpec, obj *apiv1alpha1.Foo, specPath *field.Path) field.ErrorList {
+	gp := obj.Spec.ForceDeleteGracePeriod
+	if gp == nil {
+		return nil
+	}
+	if oldSpec != nil && oldSpec.DeletionPolicy == obj.Spec.DeletionPolicy &&
+		equality.Semantic.DeepEqual(oldSpec.ForceDeleteGracePeriod, gp) {
+		return nil
+	}
+	var errs field.ErrorList
+	if gp.Duration < 0 {
+		errs = append(errs, field.Invalid(specPath.Child("forceDeleteGracePeriod"), gp.Duration.String(), "must not be negative"))
+	}
+	if obj.GetDeletionPolicy() != apiv1alpha1.DeletionPolicyForceDelete {
+		errs = append(errs, field.Forbidden(specPath.Child("forceDeleteGracePeriod"),
+			fmt.Sprintf("may only be set with deletionPolicy %s", apiv1alpha1.DeletionPolicyForceDelete)))
+	}
+	return errs
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+// validateFooUpdate rejects changes of fields that cannot be changed once set and version changes that are not a supported upgrade.
+// opencontrolplane-gen:replace Foo=KIND
+func validateFooUpdate(oldObj, newObj *apiv1alpha1.Foo) field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	// opencontrolplane-gen:replace Foo=KIND
+	if oldObj.Spec.Foo != nil && !equality.Semantic.DeepEqual(oldObj.Spec.Foo, newObj.Spec.Foo) {
+		errs = append(errs, field.Forbidden(specPath.Child("foo"), "field is immutable once set"))
+	}
+	// a requested version must be reachable from the version that is currently installed
+	if newObj.Spec.Version != "" && newObj.Spec.Version != oldObj.Spec.Version {
+		if err := domainservice.CheckUpgrade(oldObj.Status.InstalledVersion, newObj.Spec.Version); err != nil {
+			errs = append(errs, field.Forbidden(specPath.Child("version"), err.Error()))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// opencontrolplane-gen:replace Foo=KIND
+func TestFooCustomDefaulter(t *testing.T) {
+	tests := []struct {
+		name string
+		// opencontrolplane-gen:replace Foo=KIND
+		spec apiv1alpha1.FooSpec
+		// opencontrolplane-gen:replace Foo=KIND
+		want apiv1alpha1.FooSpec
+	}{
+		{
+			name: "deletion policy is defaulted",
+			// opencontrolplane-gen:replace Foo=KIND
+			spec: apiv1alpha1.FooSpec{},
+			// opencontrolplane-gen:replace Foo=KIND
+			want: apiv1alpha1.FooSpec{DeletionPolicy: apiv1alpha1.DeletionPolicyDelete},
+		},
+		{
+			name: "grace period is not stored for ForceDelete",
+			// opencontrolplane-gen:replace Foo=KIND
+			spec: apiv1alpha1.FooSpec{DeletionPolicy: apiv1alpha1.DeletionPolicyForceDelete},
+			// opencontrolplane-gen:replace Foo=KIND
+			want: apiv1alpha1.FooSpec{DeletionPolicy: apiv1alpha1.DeletionPolicyForceDelete},
+		},
+		{
+			name: "specified grace period is kept",
+			// opencontrolplane-gen:replace Foo=KIND
+			spec: apiv1alpha1.FooSpec{
+				DeletionPolicy:         apiv1alpha1.DeletionPolicyForceDelete,
+				ForceDeleteGracePeriod: &metav1.Duration{Duration: time.Minute},
+			},
+			// opencontrolplane-gen:replace Foo=KIND
+			want: apiv1alpha1.FooSpec{
+				DeletionPolicy:         apiv1alpha1.DeletionPolicyForceDelete,
+				ForceDeleteGracePeriod: &metav1.Duration{Duration: time.Minute},
+			},
+		},
+		{
+			name: "grace period is not set for Orphan",
+			// opencontrolplane-gen:replace Foo=KIND
+			spec: apiv1alpha1.FooSpec{DeletionPolicy: apiv1alpha1.DeletionPolicyOrphan},
+			// opencontrolplane-gen:replace Foo=KIND
+			want: apiv1alpha1.FooSpec{DeletionPolicy: apiv1alpha1.DeletionPolicyOrphan},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// opencontrolplane-gen:replace Foo=KIND
+			obj := &apiv1alpha1.Foo{Spec: tt.spec}
+			// opencontrolplane-gen:replace Foo=KIND
+			if err := (&FooCustomDefaulter{}).Default(context.Background(), obj); err != nil {
+				t.Fatalf("Default() error = %v", err)
+			}
+			if !equality.Semantic.DeepEqual(obj.Spec, tt.want) {
+				t.Errorf("Default() spec = %+v, want %+v", obj.Spec, tt.want)
+			}
+			// defaulted objects must pass the validation
+			// opencontrolplane-gen:replace Foo=KIND
+			if _, err := (&FooCustomValidator{}).ValidateCreate(context.Background(), obj); err != nil {
+				t.Errorf("ValidateCreate() of defaulted object error = %v", err)
+			}
+		})
+	}
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+func TestFooCustomValidatorValidateCreate(t *testing.T) {
+	tests := []struct {
+		name string
+		// opencontrolplane-gen:replace Foo=KIND
+		spec    apiv1alpha1.FooSpec
+		wantErr bool
+	}{
+		{
+			name: "empty spec",
+		},
+		{
+			name: "field set",
+			// opencontrolplane-gen:replace Foo=KIND
+			spec: apiv1alpha1.FooSpec{Foo: ptr.To("bar")},
+		},
+		{
+			name: "field set to empty string",
+			// opencontrolplane-gen:replace Foo=KIND
+			spec:    apiv1alpha1.FooSpec{Foo: ptr.To("")},
+			wantErr: true,
+		},
+		{
+			name: "grace period with ForceDelete",
+			// opencontrolplane-gen:replace Foo=KIND
+			spec: apiv1alpha1.FooSpec{
+				DeletionPolicy:         apiv1alpha1.DeletionPolicyForceDelete,
+				ForceDeleteGracePeriod: &metav1.Duration{Duration: time.Minute},
+			},
+		},
+		{
+			name: "negative grace period",
+			// opencontrolplane-gen:replace Foo=KIND
+			spec: apiv1alpha1.FooSpec{
+				DeletionPolicy:         apiv1alpha1.DeletionPolicyForceDelete,
+				ForceDeleteGracePeriod: &metav1.Duration{Duration: -time.Minute},
+			},
+			wantErr: true,
+		},
+		{
+			name: "grace period without ForceDelete",
+			// opencontrolplane-gen:replace Foo=KIND
+			spec: apiv1alpha1.FooSpec{
+				DeletionPolicy:         apiv1alpha1.DeletionPolicyDelete,
+				ForceDeleteGracePeriod: &metav1.Duration{Duration: time.Minute},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// opencontrolplane-gen:replace Foo=KIND
+			obj := &apiv1alpha1.Foo{ObjectMeta: metav1.ObjectMeta{Name: "test"}, Spec: tt.spec}
+			// opencontrolplane-gen:replace Foo=KIND
+			_, err := (&FooCustomValidator{}).ValidateCreate(context.Background(), obj)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// opencontrolplane-gen:replace Foo=KIND
+func TestFooCustomValidatorValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name string
+		// opencontrolplane-gen:replace Foo=KIND
+		old, new         apiv1alpha1.FooSpec
+		installedVersion string
+		deleting         bool
+		wantErr          bool
+	}{
+		{
+			name: "field set for the first time",
+			// opencontrolplane-gen:replace Foo=KIND
+			new: apiv1alpha1.FooSpec{Foo: ptr.To("bar")},
+		},
+		{
+			name: "immutable field changed",
+			// opencontrolplane-gen:replace Foo=KIND
+			old: apiv1alpha1.FooSpec{Foo: ptr.To("bar")},
+			// opencontrolplane-gen:replace Foo=KIND
+			new:     apiv1alpha1.FooSpec{Foo: ptr.To("baz")},
+			wantErr: true,
+		},
+		{
+			name: "immutable field removed",
+			// opencontrolplane-gen:replace Foo=KIND
+			old:     apiv1alpha1.FooSpec{Foo: ptr.To("bar")},
+			wantErr: true,
+		},
+		{
+			name: "version upgraded",
+			// opencontrolplane-gen:replace Foo=KIND
+			old: apiv1alpha1.FooSpec{Version: "v1.0.0"},
+			// opencontrolplane-gen:replace Foo=KIND
+			new:              apiv1alpha1.FooSpec{Version: "v1.1.0"},
+			installedVersion: "v1.0.0",
+		},
+		{
+			name: "version downgraded",
+			// opencontrolplane-gen:replace Foo=KIND
+			old: apiv1alpha1.FooSpec{Version: "v1.1.0"},
+			// opencontrolplane-gen:replace Foo=KIND
+			new:              apiv1alpha1.FooSpec{Version: "v1.0.0"},
+			installedVersion: "v1.1.0",
+			wantErr:          true,
+		},
+		{
+			name: "version pinned below the installed default version",
+			// opencontrolplane-gen:replace Foo=KIND
+			new:              apiv1alpha1.FooSpec{Version: "v1.0.0"},
+			installedVersion: "v1.1.0",
+			wantErr:          true,
+		},
+		{
+			name: "version set before the installation completed",
+			// opencontrolplane-gen:replace Foo=KIND
+			new: apiv1alpha1.FooSpec{Version: "v1.0.0"},
+		},
+		{
+			name: "version unset",
+			// opencontrolplane-gen:replace Foo=KIND
+			old:              apiv1alpha1.FooSpec{Version: "v1.1.0"},
+			installedVersion: "v1.1.0",
+		},
+		{
+			name: "field set to empty string",
+			// opencontrolplane-gen:replace Foo=KIND
+			new:     apiv1alpha1.FooSpec{Foo: ptr.To("")},
+			wantErr: true,
+		},
+		{
+			name: "unchanged invalid spec",
+			// opencontrolplane-gen:replace Foo=KIND
+			old: apiv1alpha1.FooSpec{Foo: ptr.To(""), ForceDeleteGracePeriod: &metav1.Duration{Duration: time.Minute}},
+			// opencontrolplane-gen:replace Foo=KIND
+			new: apiv1alpha1.FooSpec{Foo: ptr.To(""), ForceDeleteGracePeriod: &metav1.Duration{Duration: time.Minute}},
+		},
+		{
+			name: "other field changed with unchanged invalid field",
+			// opencontrolplane-gen:replace Foo=KIND
+			old: apiv1alpha1.FooSpec{Foo: ptr.To("")},
+			// opencontrolplane-gen:replace Foo=KIND
+			new: apiv1alpha1.FooSpec{Foo: ptr.To(""), Version: "v1.0.0"},
+		},
+		{
+			name: "deletion policy changed with grace period",
+			// opencontrolplane-gen:replace Foo=KIND
+			old: apiv1alpha1.FooSpec{
+				DeletionPolicy:         apiv1alpha1.DeletionPolicyForceDelete,
+				ForceDeleteGracePeriod: &metav1.Duration{Duration: time.Minute},
+			},
+			// opencontrolplane-gen:replace Foo=KIND
+			new: apiv1alpha1.FooSpec{
+				DeletionPolicy:         apiv1alpha1.DeletionPolicyOrphan,
+				ForceDeleteGracePeriod: &metav1.Duration{Duration: time.Minute},
+			},
+			wantErr: true,
+		},
+		{
+			name: "object in deletion",
+			// opencontrolplane-gen:replace Foo=KIND
+			old: apiv1alpha1.FooSpec{Foo: ptr.To("bar")},
+			// opencontrolplane-gen:replace Foo=KIND
+			new:      apiv1alpha1.FooSpec{Foo: ptr.To("baz")},
+			deleting: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// opencontrolplane-gen:replace Foo=KIND
+			oldObj := &apiv1alpha1.Foo{ObjectMeta: metav1.ObjectMeta{Name: "test"}, Spec: tt.old}
+			oldObj.Status.InstalledVersion = tt.installedVersion
+			// opencontrolplane-gen:replace Foo=KIND
+			newObj := &apiv1alpha1.Foo{ObjectMeta: metav1.ObjectMeta{Name: "test"}, Spec: tt.new}
+			newObj.Status.InstalledVersion = tt.installedVersion
+			if tt.deleting {
+				newObj.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			}
+			// opencontrolplane-gen:replace Foo=KIND
+			_, err := (&FooCustomValidator{}).ValidateUpdate(context.Background(), oldObj, newObj)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
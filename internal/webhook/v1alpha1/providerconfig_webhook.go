@@ -0,0 +1,122 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	"github.com/openmcp-project/service-provider-template/internal/webhook/selfsigned"
+)
+
+// SetupProviderConfigWebhookWithManager registers the webhooks for ProviderConfig in the manager.
+// The onboarding client is used to find service objects that still use a ProviderConfig that is about to be deleted.
+func SetupProviderConfigWebhookWithManager(mgr ctrl.Manager, onboardingClient client.Client, providerName string) error {
+	return ctrl.NewWebhookManagedBy(mgr, &apiv1alpha1.ProviderConfig{}).
+		WithDefaulter(&ProviderConfigCustomDefaulter{}).
+		WithValidator(&ProviderConfigCustomValidator{
+			OnboardingClient: onboardingClient,
+			ProviderName:     providerName,
+		}).
+		Complete()
+}
+
+// ProviderConfigValidatingWebhook describes the validating webhook for ProviderConfig for the self-signed bootstrap mode.
+func ProviderConfigValidatingWebhook() selfsigned.Webhook {
+	return selfsigned.Webhook{
+		Name:  "vproviderconfig-v1alpha1.kb.io",
+		Path:  validatePath("ProviderConfig"),
+		Rules: rules("providerconfigs", admissionregistrationv1.Create, admissionregistrationv1.Update, admissionregistrationv1.Delete),
+	}
+}
+
+// ProviderConfigMutatingWebhook describes the defaulting webhook for ProviderConfig for the self-signed bootstrap mode.
+func ProviderConfigMutatingWebhook() selfsigned.Webhook {
+	return selfsigned.Webhook{
+		Name:  "mproviderconfig-v1alpha1.kb.io",
+		Path:  mutatePath("ProviderConfig"),
+		Rules: rules("providerconfigs", admissionregistrationv1.Create, admissionregistrationv1.Update),
+	}
+}
+
+// opencontrolplane-gen:replace foo=KIND_LOWER
+// +kubebuilder:webhook:path=/mutate-foo-services-open-control-plane-io-v1alpha1-providerconfig,mutating=true,failurePolicy=fail,sideEffects=None,groups=foo.services.open-control-plane.io,resources=providerconfigs,verbs=create;update,versions=v1alpha1,name=mproviderconfig-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ProviderConfigCustomDefaulter sets default values on ProviderConfig resources when they are created or updated.
+type ProviderConfigCustomDefaulter struct{}
+
+var _ admission.Defaulter[*apiv1alpha1.ProviderConfig] = &ProviderConfigCustomDefaulter{}
+
+// Default sets the poll interval if it is not specified.
+func (d *ProviderConfigCustomDefaulter) Default(_ context.Context, pc *apiv1alpha1.ProviderConfig) error {
+	if pc.Spec.PollInterval == nil {
+		pc.Spec.PollInterval = &metav1.Duration{Duration: apiv1alpha1.DefaultPollInterval}
+	}
+	return nil
+}
+
+// opencontrolplane-gen:replace foo=KIND_LOWER
+// +kubebuilder:webhook:path=/validate-foo-services-open-control-plane-io-v1alpha1-providerconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=foo.services.open-control-plane.io,resources=providerconfigs,verbs=create;update;delete,versions=v1alpha1,name=vproviderconfig-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ProviderConfigCustomValidator validates ProviderConfig resources when they are created, updated or deleted.
+type ProviderConfigCustomValidator struct {
+	// OnboardingClient is used to list the service objects that use the ProviderConfig.
+	OnboardingClient client.Client
+	// ProviderName is the name of the provider. Service objects are reconciled with the ProviderConfig of the same name.
+	ProviderName string
+}
+
+var _ admission.Validator[*apiv1alpha1.ProviderConfig] = &ProviderConfigCustomValidator{}
+
+// ValidateCreate validates the spec of a new ProviderConfig.
+func (v *ProviderConfigCustomValidator) ValidateCreate(_ context.Context, pc *apiv1alpha1.ProviderConfig) (admission.Warnings, error) {
+	return nil, invalid(pc, pc.ValidateSpec())
+}
+
+// ValidateUpdate validates the spec of the updated ProviderConfig.
+func (v *ProviderConfigCustomValidator) ValidateUpdate(_ context.Context, _, pc *apiv1alpha1.ProviderConfig) (admission.Warnings, error) {
+	return nil, invalid(pc, pc.ValidateSpec())
+}
+
+// ValidateDelete rejects the deletion of the ProviderConfig while service objects still use it.
+func (v *ProviderConfigCustomValidator) ValidateDelete(ctx context.Context, pc *apiv1alpha1.ProviderConfig) (admission.Warnings, error) {
+	if pc.Name != v.ProviderName {
+		return nil, nil
+	}
+	// opencontrolplane-gen:replace Foo=KIND
+	list := &apiv1alpha1.FooList{}
+	if err := v.OnboardingClient.List(ctx, list); err != nil {
+		return nil, apierrors.NewInternalError(fmt.Errorf("unable to list service objects: %w", err))
+	}
+	if len(list.Items) == 0 {
+		return nil, nil
+	}
+	first := list.Items[0]
+	return nil, apierrors.NewForbidden(apiv1alpha1.GroupVersion.WithResource("providerconfigs").GroupResource(), pc.Name,
+		fmt.Errorf("still used by %d service object(s), e.g. %s/%s", len(list.Items), first.Namespace, first.Name))
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commonapi "github.com/openmcp-project/openmcp-operator/api/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+func TestProviderConfigCustomValidatorValidateSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    apiv1alpha1.ProviderConfigSpec
+		wantErr bool
+	}{
+		{
+			name: "empty spec",
+		},
+		{
+			name: "poll interval in range",
+			spec: apiv1alpha1.ProviderConfigSpec{PollInterval: &metav1.Duration{Duration: time.Minute}},
+		},
+		{
+			name:    "poll interval too short",
+			spec:    apiv1alpha1.ProviderConfigSpec{PollInterval: &metav1.Duration{Duration: time.Second}},
+			wantErr: true,
+		},
+		{
+			name:    "poll interval too long",
+			spec:    apiv1alpha1.ProviderConfigSpec{PollInterval: &metav1.Duration{Duration: 48 * time.Hour}},
+			wantErr: true,
+		},
+		{
+			name:    "image pull secret without name",
+			spec:    apiv1alpha1.ProviderConfigSpec{ImagePullSecrets: []commonapi.LocalObjectReference{{Name: "pull"}, {}}},
+			wantErr: true,
+		},
+		{
+			name: "default version is one of the versions",
+			spec: apiv1alpha1.ProviderConfigSpec{Versions: []string{"v1.0.0", "v1.1.0"}, DefaultVersion: "v1.0.0"},
+		},
+		{
+			name:    "default version is not one of the versions",
+			spec:    apiv1alpha1.ProviderConfigSpec{Versions: []string{"v1.0.0"}, DefaultVersion: "v1.1.0"},
+			wantErr: true,
+		},
+		{
+			name: "default version without restricted versions",
+			spec: apiv1alpha1.ProviderConfigSpec{DefaultVersion: "v1.1.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc := &apiv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "test"}, Spec: tt.spec}
+			v := &ProviderConfigCustomValidator{}
+			if _, err := v.ValidateCreate(context.Background(), pc); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, err := v.ValidateUpdate(context.Background(), &apiv1alpha1.ProviderConfig{}, pc); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProviderConfigCustomValidatorValidateDelete(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := apiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	// opencontrolplane-gen:replace Foo=KIND
+	svcobj := &apiv1alpha1.Foo{ObjectMeta: metav1.ObjectMeta{Name: "mcp", Namespace: "project"}}
+
+	tests := []struct {
+		name         string
+		providerName string
+		objects      []client.Object
+		wantErr      bool
+	}{
+		{
+			name:         "unused provider config",
+			providerName: "test",
+		},
+		{
+			name:         "provider config in use",
+			providerName: "test",
+			objects:      []client.Object{svcobj},
+			wantErr:      true,
+		},
+		{
+			name:         "provider config of another provider",
+			providerName: "other",
+			objects:      []client.Object{svcobj},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := &ProviderConfigCustomValidator{
+				OnboardingClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+				ProviderName:     tt.providerName,
+			}
+			pc := &apiv1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+			if _, err := v.ValidateDelete(context.Background(), pc); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDelete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
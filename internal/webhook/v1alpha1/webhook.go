@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//go:generate opencontrolplane-gen
+package v1alpha1
+
+import (
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	// opencontrolplane-gen:replace github.com/openmcp-project/service-provider-template=MODULE
+	apiv1alpha1 "github.com/openmcp-project/service-provider-template/api/v1alpha1"
+)
+
+// validatePath returns the path under which controller-runtime serves the validating webhook for the given kind.
+func validatePath(kind string) string {
+	return "/validate-" + pathSuffix(kind)
+}
+
+// mutatePath returns the path under which controller-runtime serves the defaulting webhook for the given kind.
+func mutatePath(kind string) string {
+	return "/mutate-" + pathSuffix(kind)
+}
+
+func pathSuffix(kind string) string {
+	return strings.ReplaceAll(apiv1alpha1.GroupVersion.Group, ".", "-") + "-" + apiv1alpha1.GroupVersion.Version + "-" + strings.ToLower(kind)
+}
+
+// rules returns the rules that send requests for the given resource of this API version to a webhook.
+func rules(resource string, operations ...admissionregistrationv1.OperationType) []admissionregistrationv1.RuleWithOperations {
+	return []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: operations,
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{apiv1alpha1.GroupVersion.Group},
+				APIVersions: []string{apiv1alpha1.GroupVersion.Version},
+				Resources:   []string{resource},
+			},
+		},
+	}
+}
+
+// invalid converts validation errors into an error that is returned to the API server, nil if there are no errors.
+func invalid(obj client.Object, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(obj.GetObjectKind().GroupVersionKind().GroupKind(), obj.GetName(), errs)
+}